@@ -0,0 +1,268 @@
+// Package analysis computes structural statistics of generated graphs.
+package analysis
+
+import (
+	"github.com/gammazero/deque"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"sort"
+)
+
+// Report contains structural statistics of the graph. Distances count edges and ignore weights,
+// values which aren't defined for the graph are nil.
+type Report struct {
+	Nodes               int            `json:"nodes"`
+	Edges               int            `json:"edges"`
+	Density             float64        `json:"density"`
+	MinDegree           int            `json:"min_degree"`
+	MaxDegree           int            `json:"max_degree"`
+	AverageDegree       float64        `json:"average_degree"`
+	DegreeHistogram     []int          `json:"degree_histogram"`
+	Connected           bool           `json:"connected"`
+	Components          int            `json:"components"`
+	ComponentSizes      []int          `json:"component_sizes"`
+	Diameter            *int           `json:"diameter"`
+	Radius              *int           `json:"radius"`
+	AverageShortestPath *float64       `json:"average_shortest_path"`
+	AverageClustering   float64        `json:"average_clustering"`
+	Transitivity        float64        `json:"transitivity"`
+	Assortativity       *float64       `json:"assortativity"`
+	Bipartite           bool           `json:"bipartite"`
+	BipartiteWitness    BipartiteProof `json:"bipartite_witness"`
+}
+
+// BipartiteProof proves the answer about bipartiteness, bipartite graphs have Partition
+// with side of every node, the others have OddCycle listing nodes of cycle of odd length.
+type BipartiteProof struct {
+	Partition []int `json:"partition,omitempty"`
+	OddCycle  []int `json:"odd_cycle,omitempty"`
+}
+
+// Analyze computes the report of graph.
+func Analyze(g generator.Graph) Report {
+	edges := g.Edges()
+	report := Report{Nodes: len(edges)}
+	degrees := make([]int, len(edges))
+	for k := range edges {
+		for _, ok := range edges[k] {
+			if ok {
+				degrees[k]++
+			}
+		}
+		report.Edges += degrees[k]
+	}
+	report.Edges /= 2
+
+	report.degrees(degrees)
+	report.components(edges)
+	report.distances(edges)
+	report.clustering(edges, degrees)
+	report.assortativity(edges, degrees)
+	report.bipartite(edges)
+	return report
+}
+
+func (r *Report) degrees(degrees []int) {
+	if r.Nodes > 1 {
+		r.Density = float64(2*r.Edges) / float64(r.Nodes*(r.Nodes-1))
+	}
+	if r.Nodes == 0 {
+		r.DegreeHistogram = []int{}
+		return
+	}
+	r.MinDegree, r.MaxDegree = degrees[0], degrees[0]
+	for _, d := range degrees {
+		if d < r.MinDegree {
+			r.MinDegree = d
+		}
+		if d > r.MaxDegree {
+			r.MaxDegree = d
+		}
+	}
+	r.AverageDegree = float64(2*r.Edges) / float64(r.Nodes)
+	r.DegreeHistogram = make([]int, r.MaxDegree+1)
+	for _, d := range degrees {
+		r.DegreeHistogram[d]++
+	}
+}
+
+func (r *Report) components(edges []map[int]bool) {
+	components := algorithms.Components(edges)
+	r.Components = len(components)
+	r.Connected = len(components) <= 1
+	r.ComponentSizes = make([]int, len(components))
+	for k := range components {
+		r.ComponentSizes[k] = len(components[k])
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(r.ComponentSizes)))
+}
+
+// bfs returns distances of nodes from the source, unreachable nodes have distance -1.
+// Parents of nodes in the search tree are stored into parents when it isn't nil.
+func bfs(edges []map[int]bool, source int, parents []int) []int {
+	distances := make([]int, len(edges))
+	for k := range distances {
+		distances[k] = -1
+	}
+	distances[source] = 0
+	if parents != nil {
+		parents[source] = -1
+	}
+	queue := deque.New[int]()
+	queue.PushBack(source)
+	for queue.Len() != 0 {
+		node := queue.PopFront()
+		for next, ok := range edges[node] {
+			if !ok || distances[next] >= 0 {
+				continue
+			}
+			distances[next] = distances[node] + 1
+			if parents != nil {
+				parents[next] = node
+			}
+			queue.PushBack(next)
+		}
+	}
+	return distances
+}
+
+// distances computes eccentricities by search from every node, the average shortest path
+// is taken over pairs of nodes connected by a path. Diameter and radius of disconnected
+// graphs are infinite and stay nil.
+func (r *Report) distances(edges []map[int]bool) {
+	diameter, radius := 0, len(edges)
+	sum, pairs := 0, 0
+	for k := range edges {
+		eccentricity := 0
+		for j, d := range bfs(edges, k, nil) {
+			if d <= 0 || j == k {
+				continue
+			}
+			sum += d
+			pairs++
+			if d > eccentricity {
+				eccentricity = d
+			}
+		}
+		if eccentricity > diameter {
+			diameter = eccentricity
+		}
+		if eccentricity < radius {
+			radius = eccentricity
+		}
+	}
+	if pairs != 0 {
+		average := float64(sum) / float64(pairs)
+		r.AverageShortestPath = &average
+	}
+	if r.Connected && len(edges) != 0 {
+		r.Diameter, r.Radius = &diameter, &radius
+	}
+}
+
+// clustering computes average of local clustering coefficients, nodes of degree lower
+// than two have zero coefficient, and the ratio of closed triplets.
+func (r *Report) clustering(edges []map[int]bool, degrees []int) {
+	closed, triplets, sum := 0, 0, 0.0
+	for k := range edges {
+		links := 0
+		for u, ok := range edges[k] {
+			if !ok {
+				continue
+			}
+			for v, ok := range edges[k] {
+				if ok && u < v && edges[u][v] {
+					links++
+				}
+			}
+		}
+		possible := degrees[k] * (degrees[k] - 1) / 2
+		if possible != 0 {
+			sum += float64(links) / float64(possible)
+		}
+		closed += links
+		triplets += possible
+	}
+	if len(edges) != 0 {
+		r.AverageClustering = sum / float64(len(edges))
+	}
+	if triplets != 0 {
+		r.Transitivity = float64(closed) / float64(triplets)
+	}
+}
+
+// assortativity computes Pearson correlation of degrees of the ends of edges,
+// it isn't defined for graphs whose edges all join nodes of the same degree.
+func (r *Report) assortativity(edges []map[int]bool, degrees []int) {
+	var product, sum, squares float64
+	for k := range edges {
+		for j, ok := range edges[k] {
+			if !ok || j < k {
+				continue
+			}
+			a, b := float64(degrees[k]), float64(degrees[j])
+			product += a * b
+			sum += (a + b) / 2
+			squares += (a*a + b*b) / 2
+		}
+	}
+	if r.Edges == 0 {
+		return
+	}
+	m := float64(r.Edges)
+	mean := sum / m
+	variance := squares/m - mean*mean
+	if variance <= 1e-12 {
+		return
+	}
+	result := (product/m - mean*mean) / variance
+	r.Assortativity = &result
+}
+
+// bipartite colours nodes by breadth first search, edge joining nodes of the same colour
+// closes odd cycle through the search tree.
+func (r *Report) bipartite(edges []map[int]bool) {
+	sides := make([]int, len(edges))
+	parents := make([]int, len(edges))
+	depths := make([]int, len(edges))
+	for k := range depths {
+		depths[k] = -1
+	}
+	for k := range edges {
+		if depths[k] >= 0 {
+			continue
+		}
+		for node, d := range bfs(edges, k, parents) {
+			if d >= 0 {
+				depths[node] = d
+				sides[node] = d % 2
+			}
+		}
+	}
+
+	for k := range edges {
+		for j, ok := range edges[k] {
+			if ok && sides[k] == sides[j] {
+				r.BipartiteWitness.OddCycle = oddCycle(k, j, parents)
+				return
+			}
+		}
+	}
+	r.Bipartite = true
+	r.BipartiteWitness.Partition = sides
+}
+
+// oddCycle joins paths from the ends of edge to their common ancestor in search tree,
+// the ends have the same depth as they are adjacent and have the same colour.
+func oddCycle(u, v int, parents []int) []int {
+	left, right := []int{u}, []int{v}
+	for u != v {
+		u, v = parents[u], parents[v]
+		left = append(left, u)
+		right = append(right, v)
+	}
+	for k := len(right) - 2; k >= 0; k-- {
+		left = append(left, right[k])
+	}
+	return left
+}
@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func buildGraph(size int, edges [][2]int) generator.Graph {
+	edgesMap := make([]map[int]bool, size)
+	for k := range edgesMap {
+		edgesMap[k] = make(map[int]bool)
+	}
+	for _, e := range edges {
+		edgesMap[e[0]][e[1]] = true
+		edgesMap[e[1]][e[0]] = true
+	}
+	return generator.SimpleGraph{Size: size, EdgesMap: edgesMap}
+}
+
+func TestAnalyzePath(t *testing.T) {
+	report := Analyze(buildGraph(4, [][2]int{{0, 1}, {1, 2}, {2, 3}}))
+	assert.Equal(t, 4, report.Nodes)
+	assert.Equal(t, 3, report.Edges)
+	assert.InDelta(t, 0.5, report.Density, 1e-9)
+	assert.Equal(t, []int{0, 2, 2}, report.DegreeHistogram)
+	assert.True(t, report.Connected)
+	assert.Equal(t, 3, *report.Diameter)
+	assert.Equal(t, 2, *report.Radius)
+	assert.InDelta(t, 10.0/6, *report.AverageShortestPath, 1e-9)
+	assert.Zero(t, report.AverageClustering)
+	assert.InDelta(t, -0.5, *report.Assortativity, 1e-9)
+	assert.True(t, report.Bipartite)
+	assert.Equal(t, []int{0, 1, 0, 1}, report.BipartiteWitness.Partition)
+}
+
+func TestAnalyzeOddCycle(t *testing.T) {
+	report := Analyze(buildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}}))
+	assert.Equal(t, 2, *report.Diameter)
+	assert.Equal(t, 2, *report.Radius)
+	assert.Nil(t, report.Assortativity)
+	assert.False(t, report.Bipartite)
+	cycle := report.BipartiteWitness.OddCycle
+	assert.Len(t, cycle, 5)
+	for k := range cycle {
+		assert.Contains(t, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {0, 4}, {1, 0}, {2, 1}, {3, 2}, {4, 3}, {4, 0}},
+			[2]int{cycle[k], cycle[(k+1)%len(cycle)]})
+	}
+}
+
+func TestAnalyzeDisconnected(t *testing.T) {
+	report := Analyze(buildGraph(4, [][2]int{{0, 1}, {1, 2}, {2, 0}}))
+	assert.False(t, report.Connected)
+	assert.Equal(t, 2, report.Components)
+	assert.Equal(t, []int{3, 1}, report.ComponentSizes)
+	assert.Nil(t, report.Diameter)
+	assert.Nil(t, report.Radius)
+	assert.InDelta(t, 1.0, *report.AverageShortestPath, 1e-9)
+	assert.InDelta(t, 0.75, report.AverageClustering, 1e-9)
+	assert.InDelta(t, 1.0, report.Transitivity, 1e-9)
+	assert.False(t, report.Bipartite)
+	assert.Len(t, report.BipartiteWitness.OddCycle, 3)
+}
+
+func TestAnalyzeCompleteBipartite(t *testing.T) {
+	report := Analyze(buildGraph(5, [][2]int{{0, 2}, {0, 3}, {0, 4}, {1, 2}, {1, 3}, {1, 4}}))
+	assert.Equal(t, 2, report.MinDegree)
+	assert.Equal(t, 3, report.MaxDegree)
+	assert.InDelta(t, -1.0, *report.Assortativity, 1e-9)
+	assert.True(t, report.Bipartite)
+	sides := report.BipartiteWitness.Partition
+	assert.Equal(t, sides[0], sides[1])
+	assert.NotEqual(t, sides[0], sides[2])
+	assert.Equal(t, sides[2], sides[3])
+	assert.Equal(t, sides[3], sides[4])
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	report := Analyze(buildGraph(0, nil))
+	assert.Zero(t, report.Nodes)
+	assert.Empty(t, report.DegreeHistogram)
+	assert.Nil(t, report.Diameter)
+	assert.Nil(t, report.AverageShortestPath)
+	assert.True(t, report.Bipartite)
+}
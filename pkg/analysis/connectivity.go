@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"sort"
+)
+
+// lowLinks runs Tarjan's depth first search over all components and reports articulation
+// points and bridges, found by comparing discovery times with the lowest reachable ones.
+func lowLinks(edges []map[int]bool) (points []int, bridges [][2]int) {
+	adj := adjacency(edges)
+	discovered := make([]int, len(edges))
+	low := make([]int, len(edges))
+	articulation := make([]bool, len(edges))
+	time := 0
+
+	var visit func(node, parent int)
+	visit = func(node, parent int) {
+		time++
+		discovered[node], low[node] = time, time
+		children := 0
+		for _, next := range adj[node] {
+			if next == parent {
+				continue
+			}
+			if discovered[next] != 0 {
+				if discovered[next] < low[node] {
+					low[node] = discovered[next]
+				}
+				continue
+			}
+			children++
+			visit(next, node)
+			if low[next] < low[node] {
+				low[node] = low[next]
+			}
+			if parent >= 0 && low[next] >= discovered[node] {
+				articulation[node] = true
+			}
+			if low[next] > discovered[node] {
+				bridges = append(bridges, [2]int{node, next})
+			}
+		}
+		if parent < 0 && children > 1 {
+			articulation[node] = true
+		}
+	}
+
+	for k := range edges {
+		if discovered[k] == 0 {
+			visit(k, -1)
+		}
+	}
+	for k := range articulation {
+		if articulation[k] {
+			points = append(points, k)
+		}
+	}
+	for k := range bridges {
+		if bridges[k][0] > bridges[k][1] {
+			bridges[k][0], bridges[k][1] = bridges[k][1], bridges[k][0]
+		}
+	}
+	sortEdges(bridges)
+	return
+}
+
+func solveArticulationPoints(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	points, _ := lowLinks(edges)
+	sort.Ints(points)
+	return Solution{Nodes: points}, nil
+}
+
+func solveBridges(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	_, bridges := lowLinks(edges)
+	return Solution{Edges: bridges}, nil
+}
@@ -0,0 +1,124 @@
+package analysis
+
+import "github.com/soch-fit/GraphGenerator/pkg/generator"
+
+// blossomMatcher finds maximum matching of general graph by Edmonds' blossom algorithm,
+// odd cycles found by the search are contracted into their base node.
+type blossomMatcher struct {
+	adj     [][]int
+	match   []int
+	parent  []int
+	base    []int
+	used    []bool
+	blossom []bool
+	queue   []int
+}
+
+func (m *blossomMatcher) lca(a, b int) int {
+	visited := make([]bool, len(m.adj))
+	for {
+		a = m.base[a]
+		visited[a] = true
+		if m.match[a] < 0 {
+			break
+		}
+		a = m.parent[m.match[a]]
+	}
+	for {
+		b = m.base[b]
+		if visited[b] {
+			return b
+		}
+		b = m.parent[m.match[b]]
+	}
+}
+
+func (m *blossomMatcher) markPath(node, base, child int) {
+	for m.base[node] != base {
+		m.blossom[m.base[node]], m.blossom[m.base[m.match[node]]] = true, true
+		m.parent[node] = child
+		child = m.match[node]
+		node = m.parent[m.match[node]]
+	}
+}
+
+// findPath searches for augmenting path from root and returns its free end, or -1.
+func (m *blossomMatcher) findPath(root int) int {
+	for k := range m.adj {
+		m.used[k], m.parent[k], m.base[k] = false, -1, k
+	}
+	m.used[root] = true
+	m.queue = append(m.queue[:0], root)
+	for head := 0; head < len(m.queue); head++ {
+		node := m.queue[head]
+		for _, next := range m.adj[node] {
+			if m.base[node] == m.base[next] || m.match[node] == next {
+				continue
+			}
+			if next == root || (m.match[next] >= 0 && m.parent[m.match[next]] >= 0) {
+				base := m.lca(node, next)
+				for k := range m.blossom {
+					m.blossom[k] = false
+				}
+				m.markPath(node, base, next)
+				m.markPath(next, base, node)
+				for k := range m.adj {
+					if !m.blossom[m.base[k]] {
+						continue
+					}
+					m.base[k] = base
+					if !m.used[k] {
+						m.used[k] = true
+						m.queue = append(m.queue, k)
+					}
+				}
+			} else if m.parent[next] < 0 {
+				m.parent[next] = node
+				if m.match[next] < 0 {
+					return next
+				}
+				m.used[m.match[next]] = true
+				m.queue = append(m.queue, m.match[next])
+			}
+		}
+	}
+	return -1
+}
+
+func maxMatching(edges []map[int]bool) []int {
+	size := len(edges)
+	m := blossomMatcher{
+		adj:     adjacency(edges),
+		match:   make([]int, size),
+		parent:  make([]int, size),
+		base:    make([]int, size),
+		used:    make([]bool, size),
+		blossom: make([]bool, size),
+	}
+	for k := range m.match {
+		m.match[k] = -1
+	}
+	for root := range m.adj {
+		if m.match[root] >= 0 {
+			continue
+		}
+		for node := m.findPath(root); node >= 0; {
+			previous := m.parent[node]
+			next := m.match[previous]
+			m.match[node], m.match[previous] = previous, node
+			node = next
+		}
+	}
+	return m.match
+}
+
+// solveMaxMatching finds matching with the most edges, value is the number of edges.
+func solveMaxMatching(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	var matching [][2]int
+	for node, other := range maxMatching(edges) {
+		if node < other {
+			matching = append(matching, [2]int{node, other})
+		}
+	}
+	return Solution{Edges: matching, Value: value(float64(len(matching)))}, nil
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"container/heap"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"sort"
+)
+
+// edgeWeights returns weights of all edges, edges of unweighted graphs weigh one.
+func edgeWeights(g generator.Graph, edges []map[int]bool) map[generator.WeightedEdge]float64 {
+	if g.Properties().Weighted() {
+		weights, _ := generator.FloatWeights(g)
+		return weights
+	}
+	result := make(map[generator.WeightedEdge]float64)
+	for k := range edges {
+		for next, ok := range edges[k] {
+			if ok {
+				result[generator.CreateEdge(k, next)] = 1
+			}
+		}
+	}
+	return result
+}
+
+// solveMST finds minimum spanning forest by Kruskal's algorithm, edges of equal
+// weight are taken in order of their ends. Value is the total weight.
+func solveMST(g generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	weights := edgeWeights(g, edges)
+	candidates := make([]generator.WeightedEdge, 0, len(weights))
+	for edge := range weights {
+		candidates = append(candidates, edge)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if weights[a] != weights[b] {
+			return weights[a] < weights[b]
+		}
+		if a.Left != b.Left {
+			return a.Left < b.Left
+		}
+		return a.Right < b.Right
+	})
+
+	parents := make([]int, len(edges))
+	for k := range parents {
+		parents[k] = k
+	}
+	var find func(int) int
+	find = func(node int) int {
+		if parents[node] != node {
+			parents[node] = find(parents[node])
+		}
+		return parents[node]
+	}
+
+	total := 0.0
+	var tree [][2]int
+	for _, edge := range candidates {
+		left, right := find(edge.Left), find(edge.Right)
+		if left == right {
+			continue
+		}
+		parents[left] = right
+		tree = append(tree, [2]int{edge.Left, edge.Right})
+		total += weights[edge]
+	}
+	sortEdges(tree)
+	return Solution{Edges: tree, Value: value(total)}, nil
+}
+
+type distanceItem struct {
+	node     int
+	distance float64
+}
+
+type distanceQueue []distanceItem
+
+func (q distanceQueue) Len() int {
+	return len(q)
+}
+
+func (q distanceQueue) Less(i, j int) bool {
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+	return q[i].node < q[j].node
+}
+
+func (q distanceQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *distanceQueue) Push(x any) {
+	*q = append(*q, x.(distanceItem))
+}
+
+func (q *distanceQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// solveShortestPaths computes distances from source and the tree of shortest paths by
+// Dijkstra's algorithm, negative edge of undirected graph would make the paths unbounded.
+func solveShortestPaths(g generator.Graph, edges []map[int]bool, source int) (Solution, error) {
+	weights := edgeWeights(g, edges)
+	for _, w := range weights {
+		if w < 0 {
+			return Solution{}, ErrNegativeWeight
+		}
+	}
+	adj := adjacency(edges)
+	distances := make([]float64, len(edges))
+	parents := make([]int, len(edges))
+	done := make([]bool, len(edges))
+	for k := range distances {
+		distances[k], parents[k] = -1, -1
+	}
+	distances[source] = 0
+	queue := &distanceQueue{{node: source}}
+	for queue.Len() != 0 {
+		item := heap.Pop(queue).(distanceItem)
+		if done[item.node] {
+			continue
+		}
+		done[item.node] = true
+		for _, next := range adj[item.node] {
+			distance := item.distance + weights[generator.CreateEdge(item.node, next)]
+			if done[next] || (distances[next] >= 0 && distances[next] <= distance) {
+				continue
+			}
+			distances[next], parents[next] = distance, item.node
+			heap.Push(queue, distanceItem{node: next, distance: distance})
+		}
+	}
+	return Solution{Distances: distances, Parents: parents}, nil
+}
@@ -0,0 +1,147 @@
+package analysis
+
+import (
+	"errors"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownProblem   = errors.New("unknown problem")
+	ErrInvalidSource    = errors.New("invalid source node of problem")
+	ErrSourceOutOfRange = errors.New("source node is not in the graph")
+	ErrNoEulerTour      = errors.New("graph has no Euler tour")
+	ErrNegativeWeight   = errors.New("shortest paths require non-negative weights")
+)
+
+// Solution is the answer to one problem on the graph, only fields used by the problem are set.
+// Nodes are referred to by their indices. Unreachable nodes have distance and parent -1.
+type Solution struct {
+	Problem   string    `json:"problem"`
+	Nodes     []int     `json:"nodes,omitempty"`
+	Edges     [][2]int  `json:"edges,omitempty"`
+	Distances []float64 `json:"distances,omitempty"`
+	Parents   []int     `json:"parents,omitempty"`
+	Colors    []int     `json:"colors,omitempty"`
+	Value     *float64  `json:"value,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// solver solves problem on the graph, source is zero for problems without source node.
+type solver func(g generator.Graph, edges []map[int]bool, source int) (Solution, error)
+
+type problem struct {
+	// source is set for problems taking source node as "name:node"
+	source bool
+	// sourceRequired is set for problems which can't default to node zero
+	sourceRequired bool
+	solve          solver
+}
+
+var problems = map[string]problem{
+	"mst":                 {solve: solveMST},
+	"shortest-paths-from": {source: true, sourceRequired: true, solve: solveShortestPaths},
+	"bfs-order":           {source: true, solve: solveBFSOrder},
+	"dfs-order":           {source: true, solve: solveDFSOrder},
+	"topological-order":   {solve: solveTopologicalOrder},
+	"euler-tour":          {solve: solveEulerTour},
+	"max-matching":        {solve: solveMaxMatching},
+	"greedy-coloring":     {solve: solveGreedyColoring},
+	"articulation-points": {solve: solveArticulationPoints},
+	"bridges":             {solve: solveBridges},
+}
+
+// Problems lists names of the problems which can be solved, sorted.
+func Problems() []string {
+	result := make([]string, 0, len(problems))
+	for name := range problems {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ParseProblem splits problem written as "name" or "name:source" and checks that it is known.
+func ParseProblem(problem string) (name string, source int, err error) {
+	name, rawSource, hasSource := strings.Cut(problem, ":")
+	info, ok := problems[name]
+	if !ok {
+		return name, 0, ErrUnknownProblem
+	}
+	if !hasSource {
+		if info.sourceRequired {
+			return name, 0, ErrInvalidSource
+		}
+		return name, 0, nil
+	}
+	source, err = strconv.Atoi(rawSource)
+	if !info.source || err != nil || source < 0 {
+		return name, 0, ErrInvalidSource
+	}
+	return name, source, nil
+}
+
+// Solve solves the problems on graph in the order of request, problems without
+// solution have the reason stored in the Error of their solution.
+func Solve(g generator.Graph, requested []string) []Solution {
+	if len(requested) == 0 {
+		return nil
+	}
+	edges := g.Edges()
+	result := make([]Solution, len(requested))
+	for k, name := range requested {
+		result[k] = solve(g, edges, name)
+	}
+	return result
+}
+
+func solve(g generator.Graph, edges []map[int]bool, requested string) Solution {
+	name, source, err := ParseProblem(requested)
+	if err == nil && source >= len(edges) && problems[name].source {
+		err = ErrSourceOutOfRange
+	}
+	if err != nil {
+		return Solution{Problem: requested, Error: err.Error()}
+	}
+	solution, err := problems[name].solve(g, edges, source)
+	solution.Problem = requested
+	if err != nil {
+		solution.Error = err.Error()
+	}
+	return solution
+}
+
+// neighbours returns sorted neighbours of node, so that the solutions don't depend on map order.
+func neighbours(edges []map[int]bool, node int) []int {
+	result := make([]int, 0, len(edges[node]))
+	for next, ok := range edges[node] {
+		if ok {
+			result = append(result, next)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func adjacency(edges []map[int]bool) [][]int {
+	result := make([][]int, len(edges))
+	for k := range edges {
+		result[k] = neighbours(edges, k)
+	}
+	return result
+}
+
+func sortEdges(edges [][2]int) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+}
+
+func value(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseProblem(t *testing.T) {
+	name, source, err := ParseProblem("shortest-paths-from:3")
+	assert.NoError(t, err)
+	assert.Equal(t, "shortest-paths-from", name)
+	assert.Equal(t, 3, source)
+	_, source, err = ParseProblem("bfs-order")
+	assert.NoError(t, err)
+	assert.Zero(t, source)
+
+	for problem, expected := range map[string]error{
+		"shortest-paths-from":    ErrInvalidSource,
+		"shortest-paths-from:-1": ErrInvalidSource,
+		"mst:2":                  ErrInvalidSource,
+		"bfs-order:x":            ErrInvalidSource,
+		"travelling-salesman":    ErrUnknownProblem,
+	} {
+		_, _, err = ParseProblem(problem)
+		assert.ErrorIs(t, err, expected, problem)
+	}
+}
+
+func TestSolveTraversals(t *testing.T) {
+	// two triangles sharing node 2 and pendant node 5
+	g := buildGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 2}, {4, 5}})
+	solutions := Solve(g, []string{"bfs-order:2", "dfs-order", "topological-order", "greedy-coloring",
+		"articulation-points", "bridges", "euler-tour", "bfs-order:6"})
+	assert.Equal(t, []int{2, 0, 1, 3, 4, 5}, solutions[0].Nodes)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, solutions[1].Nodes)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, solutions[2].Nodes)
+	assert.Equal(t, []int{0, 1, 2, 0, 1, 0}, solutions[3].Colors)
+	assert.Equal(t, 3.0, *solutions[3].Value)
+	assert.Equal(t, []int{2, 4}, solutions[4].Nodes)
+	assert.Equal(t, [][2]int{{4, 5}}, solutions[5].Edges)
+	assert.Equal(t, ErrNoEulerTour.Error(), solutions[6].Error)
+	assert.Equal(t, ErrSourceOutOfRange.Error(), solutions[7].Error)
+	assert.Equal(t, "bfs-order:6", solutions[7].Problem)
+}
+
+func TestSolveEulerTour(t *testing.T) {
+	g := buildGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 2}})
+	tour := Solve(g, []string{"euler-tour"})[0].Nodes
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 2, 0}, tour)
+
+	disconnected := buildGraph(6, [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}})
+	assert.Equal(t, ErrNoEulerTour.Error(), Solve(disconnected, []string{"euler-tour"})[0].Error)
+}
+
+func TestSolveWeighted(t *testing.T) {
+	simple := buildGraph(4, [][2]int{{0, 1}, {1, 2}, {2, 3}, {0, 3}, {0, 2}})
+	g := generator.WeightedGraph{ParentGraph: simple, WeightsMap: map[generator.WeightedEdge]int{
+		generator.CreateEdge(0, 1): 1, generator.CreateEdge(1, 2): 2, generator.CreateEdge(2, 3): 1,
+		generator.CreateEdge(0, 3): 5, generator.CreateEdge(0, 2): 4,
+	}}
+	solutions := Solve(g, []string{"mst", "shortest-paths-from:0"})
+	assert.Equal(t, [][2]int{{0, 1}, {1, 2}, {2, 3}}, solutions[0].Edges)
+	assert.Equal(t, 4.0, *solutions[0].Value)
+	assert.Equal(t, []float64{0, 1, 3, 4}, solutions[1].Distances)
+	assert.Equal(t, []int{-1, 0, 1, 2}, solutions[1].Parents)
+
+	g.WeightsMap[generator.CreateEdge(0, 3)] = -1
+	assert.Equal(t, ErrNegativeWeight.Error(), Solve(g, []string{"shortest-paths-from:0"})[0].Error)
+
+	unreachable := Solve(buildGraph(3, [][2]int{{0, 1}}), []string{"shortest-paths-from:1", "mst"})
+	assert.Equal(t, []float64{1, 0, -1}, unreachable[0].Distances)
+	assert.Equal(t, 1.0, *unreachable[1].Value)
+}
+
+func TestSolveMaxMatching(t *testing.T) {
+	// odd cycle with pendant nodes needs blossom contraction, perfect matching has four edges
+	g := buildGraph(8, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}, {0, 5}, {2, 6}, {6, 7}})
+	solution := Solve(g, []string{"max-matching"})[0]
+	assert.Equal(t, 4.0, *solution.Value)
+	covered := make(map[int]bool)
+	for _, edge := range solution.Edges {
+		assert.True(t, g.Edges()[edge[0]][edge[1]])
+		assert.False(t, covered[edge[0]] || covered[edge[1]])
+		covered[edge[0]], covered[edge[1]] = true, true
+	}
+
+	petersen := buildGraph(10, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}, {0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+		{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5}})
+	assert.Equal(t, 5.0, *Solve(petersen, []string{"max-matching"})[0].Value)
+	assert.Nil(t, Solve(petersen, nil))
+}
@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"github.com/gammazero/deque"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+)
+
+// solveBFSOrder lists nodes reachable from source in order of breadth first search,
+// neighbours are visited in ascending order.
+func solveBFSOrder(_ generator.Graph, edges []map[int]bool, source int) (Solution, error) {
+	adj := adjacency(edges)
+	visited := make([]bool, len(edges))
+	visited[source] = true
+	order := []int{source}
+	for k := 0; k < len(order); k++ {
+		for _, next := range adj[order[k]] {
+			if !visited[next] {
+				visited[next] = true
+				order = append(order, next)
+			}
+		}
+	}
+	return Solution{Nodes: order}, nil
+}
+
+// solveDFSOrder lists nodes reachable from source in preorder of depth first search,
+// neighbours are visited in ascending order.
+func solveDFSOrder(_ generator.Graph, edges []map[int]bool, source int) (Solution, error) {
+	adj := adjacency(edges)
+	visited := make([]bool, len(edges))
+	next := make([]int, len(edges))
+	visited[source] = true
+	order, stack := []int{source}, []int{source}
+	for len(stack) != 0 {
+		node := stack[len(stack)-1]
+		if next[node] == len(adj[node]) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		child := adj[node][next[node]]
+		next[node]++
+		if !visited[child] {
+			visited[child] = true
+			order = append(order, child)
+			stack = append(stack, child)
+		}
+	}
+	return Solution{Nodes: order}, nil
+}
+
+// solveTopologicalOrder orients every edge from the lower index to the higher one, which
+// makes the graph acyclic, and orders the nodes by Kahn's algorithm with FIFO queue.
+func solveTopologicalOrder(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	adj := adjacency(edges)
+	inDegree := make([]int, len(edges))
+	for k := range adj {
+		for _, next := range adj[k] {
+			if next > k {
+				inDegree[next]++
+			}
+		}
+	}
+	queue := deque.New[int]()
+	for k := range inDegree {
+		if inDegree[k] == 0 {
+			queue.PushBack(k)
+		}
+	}
+	order := make([]int, 0, len(edges))
+	for queue.Len() != 0 {
+		node := queue.PopFront()
+		order = append(order, node)
+		for _, next := range adj[node] {
+			if next < node {
+				continue
+			}
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				queue.PushBack(next)
+			}
+		}
+	}
+	return Solution{Nodes: order}, nil
+}
+
+// solveEulerTour finds closed walk using every edge once by Hierholzer's algorithm, it starts
+// in the first node with an edge. Graphs with odd degree or edges in more components have none.
+func solveEulerTour(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	adj := adjacency(edges)
+	count, start := 0, -1
+	for k := range adj {
+		if len(adj[k])%2 != 0 {
+			return Solution{}, ErrNoEulerTour
+		}
+		if start < 0 && len(adj[k]) != 0 {
+			start = k
+		}
+		count += len(adj[k])
+	}
+	if start < 0 {
+		return Solution{}, nil
+	}
+
+	used := make(map[generator.WeightedEdge]bool, count/2)
+	next := make([]int, len(adj))
+	tour, stack := make([]int, 0, count/2+1), []int{start}
+	for len(stack) != 0 {
+		node := stack[len(stack)-1]
+		for next[node] < len(adj[node]) && used[generator.CreateEdge(node, adj[node][next[node]])] {
+			next[node]++
+		}
+		if next[node] == len(adj[node]) {
+			tour = append(tour, node)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		other := adj[node][next[node]]
+		used[generator.CreateEdge(node, other)] = true
+		stack = append(stack, other)
+	}
+	if len(tour) != count/2+1 {
+		return Solution{}, ErrNoEulerTour
+	}
+	for i, j := 0, len(tour)-1; i < j; i, j = i+1, j-1 {
+		tour[i], tour[j] = tour[j], tour[i]
+	}
+	return Solution{Nodes: tour}, nil
+}
+
+// solveGreedyColoring colours nodes in order of their indices with the lowest colour
+// not used by neighbours, value is the number of colours.
+func solveGreedyColoring(_ generator.Graph, edges []map[int]bool, _ int) (Solution, error) {
+	colors := make([]int, len(edges))
+	count := 0
+	for k := range edges {
+		used := make(map[int]bool)
+		for next, ok := range edges[k] {
+			if ok && next < k {
+				used[colors[next]] = true
+			}
+		}
+		for used[colors[k]] {
+			colors[k]++
+		}
+		if colors[k]+1 > count {
+			count = colors[k] + 1
+		}
+	}
+	return Solution{Colors: colors, Value: value(float64(count))}, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VertexWeightAttribute is the name under which the vertex weights are emitted
+// together with other attributes of vertices.
+const VertexWeightAttribute = "weight"
+
+// vertexAttributes merges weight and attributes of every vertex, nil if the graph has neither.
+// Attributes of validated graphs can't collide with the weight as its name is reserved.
+func vertexAttributes(g generator.Graph) []generator.Attributes {
+	weights, decimals := generator.VertexWeights(g)
+	attributes := generator.VertexAttributes(g)
+	if weights == nil && attributes == nil {
+		return nil
+	}
+	result := make([]generator.Attributes, len(g.Edges()))
+	for k := range result {
+		result[k] = generator.Attributes{}
+		if k < len(attributes) {
+			for name, v := range attributes[k] {
+				result[k][name] = v
+			}
+		}
+		if k >= len(weights) {
+			continue
+		}
+		if decimals == 0 {
+			result[k][VertexWeightAttribute] = generator.IntAttr(int(math.Round(weights[k])))
+		} else {
+			result[k][VertexWeightAttribute] = generator.FloatAttr(weights[k])
+		}
+	}
+	return result
+}
+
+// sortedAttributeNames returns names of the attributes in alphabetical order.
+func sortedAttributeNames(attributes generator.Attributes) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// quotedAttribute prints strings quoted and numbers as they are.
+func quotedAttribute(a generator.Attribute) string {
+	if a.Kind == generator.StringKind {
+		return strconv.Quote(a.Str)
+	}
+	return a.String()
+}
+
+// attributeName quotes name which isn't valid attribute name, so it can't break the output.
+// Reserved names are written as they are, they are used for weights of vertices.
+func attributeName(name string) string {
+	if generator.ValidAttributeName(name) || generator.ReservedAttributes[name] {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// formatAttributes prints the attributes sorted by name as name=value pairs
+// separated by sep, values are printed with valueFormat and invalid names are quoted.
+func formatAttributes(attributes generator.Attributes, sep string, valueFormat func(generator.Attribute) string) string {
+	builder := strings.Builder{}
+	for k, name := range sortedAttributeNames(attributes) {
+		if k != 0 {
+			builder.WriteString(sep)
+		}
+		builder.WriteString(attributeName(name))
+		builder.WriteByte('=')
+		builder.WriteString(valueFormat(attributes[name]))
+	}
+	return builder.String()
+}
+
+// attributeValues converts the attributes to plain values for JSON encoding.
+func attributeValues(attributes generator.Attributes) map[string]any {
+	result := make(map[string]any, len(attributes))
+	for name, v := range attributes {
+		result[name] = v.Value()
+	}
+	return result
+}
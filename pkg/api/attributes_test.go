@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttributeNames(t *testing.T) {
+	attributes := generator.Attributes{"color": generator.StringAttr("red"), "a]=b": generator.IntAttr(1), "weight": generator.IntAttr(2)}
+	assert.Equal(t, `"a]=b"="1", color="red", weight="2"`, formatAttributes(attributes, ", ", dotAttribute))
+
+	graph, _ := generator.FindAttributed(attributedTestGraph())
+	assert.NoError(t, graph.Validate())
+	graph.VertexAttributes = []generator.Attributes{{}, {"weight": generator.IntAttr(1)}, {}}
+	assert.ErrorIs(t, graph.Validate(), generator.ErrInvalidAttribute)
+	graph.VertexAttributes = nil
+	graph.EdgeAttributes = map[generator.WeightedEdge]generator.Attributes{{Left: 0, Right: 1}: {"label": generator.StringAttr("x")}}
+	assert.ErrorIs(t, graph.Validate(), generator.ErrInvalidAttribute)
+	graph.EdgeAttributes = map[generator.WeightedEdge]generator.Attributes{{Left: 0, Right: 1}: {"1st": generator.IntAttr(1)}}
+	assert.ErrorIs(t, graph.Validate(), generator.ErrInvalidAttribute)
+}
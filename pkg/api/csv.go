@@ -0,0 +1,97 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"strconv"
+)
+
+func (c *CSVGraph) Extension() string {
+	return "zip"
+}
+
+func (c *CSVGraph) Kind() string {
+	return "csv"
+}
+
+func (c *CSVGraph) ContentType() string {
+	return "application/zip"
+}
+
+func (c *CSVGraph) Convert(g generator.Graph) bool {
+	c.data = newExportData(g)
+	return true
+}
+
+// nodeRecords returns rows of nodes.csv, the header is followed by one row per node
+// with its index, label and values of all declared attributes, missing values are empty.
+func (c *CSVGraph) nodeRecords() [][]string {
+	d := &c.data
+	header := []string{"id", "label"}
+	for _, v := range d.vertexDecls {
+		header = append(header, v.Name)
+	}
+	records := [][]string{header}
+	for k := range d.labels {
+		record := []string{strconv.Itoa(k), d.labels[k]}
+		for _, decl := range d.vertexDecls {
+			v, _ := d.vertexAttr(k, decl.Name)
+			record = append(record, v.String())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// edgeRecords returns rows of edges.csv with indexes of end nodes, weight and attributes.
+func (c *CSVGraph) edgeRecords() [][]string {
+	d := &c.data
+	header := []string{"source", "target"}
+	if d.weighted {
+		header = append(header, "weight")
+	}
+	for _, v := range d.edgeDecls {
+		header = append(header, v.Name)
+	}
+	records := [][]string{header}
+	for _, e := range d.edges {
+		record := []string{strconv.Itoa(e.Left), strconv.Itoa(e.Right)}
+		if d.weighted {
+			record = append(record, d.weight(e))
+		}
+		for _, decl := range d.edgeDecls {
+			record = append(record, d.edgeAttrs[e][decl.Name].String())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// Serialize writes ZIP archive with nodes.csv and edges.csv.
+func (c *CSVGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	archive := zip.NewWriter(writer)
+	files := []struct {
+		name    string
+		records [][]string
+	}{{"nodes.csv", c.nodeRecords()}, {"edges.csv", c.edgeRecords()}}
+	for _, f := range files {
+		w, err := archive.Create(f.name)
+		if err != nil {
+			return writer, err
+		}
+		err = csv.NewWriter(w).WriteAll(f.records)
+		if err != nil {
+			return writer, err
+		}
+	}
+	return writer, archive.Close()
+}
+
+func (c *CSVGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	c.Serialize(&buffer)
+	return buffer.Bytes()
+}
@@ -0,0 +1,312 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// scriptBatchSize is the number of rows inserted by one statement of database scripts.
+const scriptBatchSize = 500
+
+// scriptIDs holds identifiers of graph and its batch written to the database scripts, nil if unknown.
+type scriptIDs struct {
+	graphID *uint32
+	batchID *uint32
+}
+
+func (s *scriptIDs) setRequest(request *GraphRequest) {
+	s.graphID, s.batchID = nil, nil
+	if request != nil {
+		id := request.ID
+		s.graphID, s.batchID = &id, request.BatchId
+	}
+}
+
+// comment describes the graph in the header of script.
+func (s *scriptIDs) comment() string {
+	result := "generated graph"
+	if s.graphID != nil {
+		result += fmt.Sprintf(" %d", *s.graphID)
+	}
+	if s.batchID != nil {
+		result += fmt.Sprintf(" of batch %d", *s.batchID)
+	}
+	return result
+}
+
+// ownAttributes returns attributes of vertex without its weight, which is stored separately.
+func (d *exportData) ownAttributes(k int) generator.Attributes {
+	if k >= len(d.vertexAttrs) {
+		return nil
+	}
+	if d.vertexWeights == nil {
+		return d.vertexAttrs[k]
+	}
+	result := make(generator.Attributes, len(d.vertexAttrs[k]))
+	for name, v := range d.vertexAttrs[k] {
+		if name != VertexWeightAttribute {
+			result[name] = v
+		}
+	}
+	return result
+}
+
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func sqlID(id *uint32) string {
+	if id == nil {
+		return "NULL"
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// sqlAttributes stores attributes as JSON text, NULL if there are none.
+func sqlAttributes(attributes generator.Attributes) string {
+	if len(attributes) == 0 {
+		return "NULL"
+	}
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(attributeValues(attributes))
+	return sqlString(strings.TrimSuffix(b.String(), "\n"))
+}
+
+const sqlSchema = `CREATE TABLE IF NOT EXISTS nodes (
+    graph_id BIGINT,
+    batch_id BIGINT,
+    node_id INTEGER NOT NULL,
+    label VARCHAR(255),
+    weight DOUBLE PRECISION,
+    attributes TEXT
+);
+CREATE TABLE IF NOT EXISTS edges (
+    graph_id BIGINT,
+    batch_id BIGINT,
+    source_id INTEGER NOT NULL,
+    target_id INTEGER NOT NULL,
+    weight DOUBLE PRECISION,
+    attributes TEXT
+);
+`
+
+// writeInserts writes the rows by INSERT statements of at most scriptBatchSize rows.
+func writeInserts(builder *strings.Builder, table, columns string, rows []string) {
+	for start := 0; start < len(rows); start += scriptBatchSize {
+		end := start + scriptBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		builder.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES\n    ", table, columns))
+		builder.WriteString(strings.Join(rows[start:end], ",\n    "))
+		builder.WriteString(";\n")
+	}
+}
+
+func (s *SQLGraph) Extension() string {
+	return "sql"
+}
+
+func (s *SQLGraph) Kind() string {
+	return "sql"
+}
+
+func (s *SQLGraph) ContentType() string {
+	return "application/sql"
+}
+
+// Concatenable is true, the tables are created only if they don't exist.
+func (s *SQLGraph) Concatenable() bool {
+	return true
+}
+
+func (s *SQLGraph) SetRequest(request *GraphRequest) {
+	s.ids.setRequest(request)
+}
+
+func (s *SQLGraph) Convert(g generator.Graph) bool {
+	s.data = newExportData(g)
+	return true
+}
+
+// Serialize writes DDL of nodes and edges tables followed by inserts of the graph, attributes
+// are stored as JSON text and missing labels, weights and identifiers as NULL.
+func (s *SQLGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	d := &s.data
+	graphID, batchID := sqlID(s.ids.graphID), sqlID(s.ids.batchID)
+	builder := strings.Builder{}
+	builder.WriteString("-- " + s.ids.comment() + "\n")
+	builder.WriteString(sqlSchema)
+
+	rows := make([]string, len(d.labels))
+	for k := range d.labels {
+		label, weight := "NULL", "NULL"
+		if d.named {
+			label = sqlString(d.labels[k])
+		}
+		if d.vertexWeights != nil {
+			weight = d.vertexWeight(k)
+		}
+		rows[k] = fmt.Sprintf("(%s, %s, %d, %s, %s, %s)", graphID, batchID, k, label, weight, sqlAttributes(d.ownAttributes(k)))
+	}
+	writeInserts(&builder, "nodes", "graph_id, batch_id, node_id, label, weight, attributes", rows)
+
+	rows = make([]string, len(d.edges))
+	for k, e := range d.edges {
+		weight := "NULL"
+		if d.weighted {
+			weight = d.weight(e)
+		}
+		rows[k] = fmt.Sprintf("(%s, %s, %d, %d, %s, %s)", graphID, batchID, e.Left, e.Right, weight, sqlAttributes(d.edgeAttrs[e]))
+	}
+	writeInserts(&builder, "edges", "graph_id, batch_id, source_id, target_id, weight, attributes", rows)
+
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (s *SQLGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	s.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func cypherString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+func cypherValue(a generator.Attribute) string {
+	if a.Kind == generator.StringKind {
+		return cypherString(a.Str)
+	}
+	return a.String()
+}
+
+// cypherMap writes map literal with keys sorted, all keys are escaped by backticks.
+func cypherMap(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, len(keys))
+	for k, v := range keys {
+		items[k] = "`" + strings.ReplaceAll(v, "`", "``") + "`: " + values[v]
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// cypherProperties converts attributes with the reserved properties, the reserved ones take precedence.
+func (c *CypherGraph) cypherProperties(attributes generator.Attributes, reserved map[string]string) map[string]string {
+	result := make(map[string]string, len(attributes)+len(reserved)+2)
+	for name, v := range attributes {
+		result[name] = cypherValue(v)
+	}
+	if c.ids.graphID != nil {
+		result["graph_id"] = sqlID(c.ids.graphID)
+	}
+	if c.ids.batchID != nil {
+		result["batch_id"] = sqlID(c.ids.batchID)
+	}
+	for k, v := range reserved {
+		result[k] = v
+	}
+	return result
+}
+
+// writeUnwind writes the rows by UNWIND statements of at most scriptBatchSize rows.
+func writeUnwind(builder *strings.Builder, rows []string, statement string) {
+	for start := 0; start < len(rows); start += scriptBatchSize {
+		end := start + scriptBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		builder.WriteString("UNWIND [\n  ")
+		builder.WriteString(strings.Join(rows[start:end], ",\n  "))
+		builder.WriteString("\n] AS row\n")
+		builder.WriteString(statement)
+	}
+}
+
+func (c *CypherGraph) Extension() string {
+	return "cypher"
+}
+
+func (c *CypherGraph) Kind() string {
+	return "cypher"
+}
+
+func (c *CypherGraph) ContentType() string {
+	return "text/plain"
+}
+
+// Concatenable is true, graphs are distinguished by their identifiers.
+func (c *CypherGraph) Concatenable() bool {
+	return true
+}
+
+func (c *CypherGraph) SetRequest(request *GraphRequest) {
+	c.ids.setRequest(request)
+}
+
+func (c *CypherGraph) Convert(g generator.Graph) bool {
+	c.data = newExportData(g)
+	return true
+}
+
+// Serialize writes script creating nodes labeled Node and relationships of type EDGE, their
+// properties are the attributes with identifiers of graph and batch. Relationships are created
+// in direction from the smaller node, nodes are matched by graph_id, if known, and node_id.
+func (c *CypherGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	d := &c.data
+	builder := strings.Builder{}
+	builder.WriteString("// " + c.ids.comment() + "\n")
+
+	rows := make([]string, len(d.labels))
+	for k := range d.labels {
+		reserved := map[string]string{"node_id": strconv.Itoa(k)}
+		if d.named {
+			reserved["label"] = cypherString(d.labels[k])
+		}
+		var attributes generator.Attributes
+		if k < len(d.vertexAttrs) {
+			// weight of vertex is one of the properties
+			attributes = d.vertexAttrs[k]
+		}
+		rows[k] = cypherMap(c.cypherProperties(attributes, reserved))
+	}
+	writeUnwind(&builder, rows, "CREATE (n:Node) SET n = row;\n")
+
+	rows = make([]string, len(d.edges))
+	for k, e := range d.edges {
+		reserved := map[string]string{"source": strconv.Itoa(e.Left), "target": strconv.Itoa(e.Right)}
+		if d.weighted {
+			reserved["weight"] = d.weight(e)
+		}
+		rows[k] = cypherMap(c.cypherProperties(d.edgeAttrs[e], reserved))
+	}
+	match := "node_id: row.source"
+	other := "node_id: row.target"
+	if c.ids.graphID != nil {
+		match = "graph_id: row.graph_id, " + match
+		other = "graph_id: row.graph_id, " + other
+	}
+	writeUnwind(&builder, rows, fmt.Sprintf("MATCH (a:Node {%s}), (b:Node {%s})\nCREATE (a)-[r:EDGE]->(b) SET r = row;\n", match, other))
+
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (c *CypherGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	c.Serialize(&buffer)
+	return buffer.Bytes()
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSQL(t *testing.T) {
+	batch := uint32(9)
+	translator := &SQLGraph{}
+	translator.SetRequest(&GraphRequest{ID: 5, BatchId: &batch})
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := string(translator.Bytes())
+	assert.True(t, strings.HasPrefix(out, "-- generated graph 5 of batch 9\n"+sqlSchema))
+	assert.Contains(t, out, "INSERT INTO nodes (graph_id, batch_id, node_id, label, weight, attributes) VALUES\n"+
+		"    (5, 9, 0, 'a', 1, '{\"color\":\"red & blue\"}'),\n"+
+		"    (5, 9, 1, 'b', 2, NULL),\n"+
+		"    (5, 9, 2, 'c', 3, NULL);\n")
+	assert.Contains(t, out, "INSERT INTO edges (graph_id, batch_id, source_id, target_id, weight, attributes) VALUES\n"+
+		"    (5, 9, 0, 1, 1.5, NULL),\n"+
+		"    (5, 9, 1, 2, -2.0, '{\"capacity\":4}');\n")
+
+	translator.SetRequest(nil)
+	translator.Convert(graphFromEdges(scriptBatchSize+1, nil))
+	out = string(translator.Bytes())
+	assert.Equal(t, 2, strings.Count(out, "INSERT INTO nodes"))
+	assert.Equal(t, 0, strings.Count(out, "INSERT INTO edges"))
+	assert.Contains(t, out, "(NULL, NULL, 500, NULL, NULL, NULL);\n")
+}
+
+func TestCypher(t *testing.T) {
+	translator := &CypherGraph{}
+	translator.SetRequest(&GraphRequest{ID: 5})
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := string(translator.Bytes())
+	assert.Equal(t, "// generated graph 5\n"+
+		"UNWIND [\n"+
+		"  {`color`: 'red & blue', `graph_id`: 5, `label`: 'a', `node_id`: 0, `weight`: 1},\n"+
+		"  {`graph_id`: 5, `label`: 'b', `node_id`: 1, `weight`: 2},\n"+
+		"  {`graph_id`: 5, `label`: 'c', `node_id`: 2, `weight`: 3}\n"+
+		"] AS row\n"+
+		"CREATE (n:Node) SET n = row;\n"+
+		"UNWIND [\n"+
+		"  {`graph_id`: 5, `source`: 0, `target`: 1, `weight`: 1.5},\n"+
+		"  {`capacity`: 4, `graph_id`: 5, `source`: 1, `target`: 2, `weight`: -2.0}\n"+
+		"] AS row\n"+
+		"MATCH (a:Node {graph_id: row.graph_id, node_id: row.source}), (b:Node {graph_id: row.graph_id, node_id: row.target})\n"+
+		"CREATE (a)-[r:EDGE]->(b) SET r = row;\n", out)
+
+	translator.SetRequest(nil)
+	translator.Convert(trianglePlusNode)
+	out = string(translator.Bytes())
+	assert.Contains(t, out, "MATCH (a:Node {node_id: row.source}), (b:Node {node_id: row.target})\n")
+	assert.NotContains(t, out, "graph_id")
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"math"
+)
+
+type DistributionType uint8
+
+const (
+	UniformDist DistributionType = iota
+	NormalDist
+	ExponentialDist
+	ZipfDist
+	HistogramDist
+)
+
+var distributionToString = map[DistributionType]string{
+	UniformDist:     "uniform",
+	NormalDist:      "normal",
+	ExponentialDist: "exponential",
+	ZipfDist:        "zipf",
+	HistogramDist:   "histogram"}
+
+var stringToDistribution = map[string]DistributionType{
+	"uniform":     UniformDist,
+	"normal":      NormalDist,
+	"exponential": ExponentialDist,
+	"zipf":        ZipfDist,
+	"histogram":   HistogramDist}
+
+func (d DistributionType) String() string {
+	return distributionToString[d]
+}
+
+func (d DistributionType) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('"')
+	buffer.WriteString(d.String())
+	buffer.WriteByte('"')
+	return buffer.Bytes(), nil
+}
+
+func (d *DistributionType) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	val, ok := stringToDistribution[str]
+	if !ok {
+		return ErrInvalidDistribution
+	}
+	*d = val
+	return nil
+}
+
+// HistogramBin is one value of user provided discrete distribution
+// drawn with probability proportional to Weight.
+type HistogramBin struct {
+	Value  int     `json:"value"`
+	Weight float64 `json:"weight"`
+}
+
+// WeightDistribution describes how the edge weights are drawn, all the distributions except
+// the histogram are clipped to the WeightMin and WeightMax of request.
+// Only the parameters of selected distribution are used.
+type WeightDistribution struct {
+	Type      DistributionType `json:"type"`
+	Mean      float64          `json:"mean,omitempty"`
+	StdDev    float64          `json:"stddev,omitempty"`
+	Rate      float64          `json:"rate,omitempty"`
+	Exponent  float64          `json:"exponent,omitempty"`
+	Histogram []HistogramBin   `json:"histogram,omitempty"`
+}
+
+func (g *GraphRequest) validHistogram() bool {
+	total := 0.0
+	nonZero := false
+	for _, v := range g.WeightDistribution.Histogram {
+		if v.Weight < 0 {
+			return false
+		}
+		if v.Value == 0 && !g.AllowZero {
+			continue
+		}
+		total += v.Weight
+		nonZero = nonZero || v.Weight > 0
+	}
+	return nonZero && total > 0
+}
+
+func (g *GraphRequest) validDistribution() bool {
+	dist := g.WeightDistribution
+	if dist != nil && dist.Type == HistogramDist {
+		return g.validHistogram()
+	}
+	valid := g.validWeight()
+	if dist != nil {
+		switch dist.Type {
+		case UniformDist:
+		case NormalDist:
+			valid = valid && dist.StdDev > 0
+		case ExponentialDist:
+			valid = valid && dist.Rate > 0
+		case ZipfDist:
+			valid = valid && dist.Exponent > 1
+		default:
+			return false
+		}
+	}
+	return valid && (g.AllowZero || g.zeroProbability() <= maxZeroProbability)
+}
+
+// maxZeroProbability is the largest permitted probability of drawing zero weight when zeros
+// aren't allowed. Zeros are drawn again, so distributions producing almost only zeros would fail.
+const maxZeroProbability = 0.99
+
+// zipfTerms limits the number of terms summed to normalize Zipf distribution,
+// the sum is then slightly smaller and the probability of zero larger.
+const zipfTerms = 10000
+
+// zeroProbability returns the probability that the weight drawn from the distribution
+// of request is zero, after it is clipped to the weight bounds and rounded.
+func (g *GraphRequest) zeroProbability() float64 {
+	min, max := float64(g.WeightMin), float64(g.WeightMax)
+	if min > 0 || max < 0 {
+		return 0
+	}
+	if min == max {
+		return 1
+	}
+	// drawn values in [lo, hi) become zero, values beyond zero bound are clipped to it
+	half := 0.5
+	if g.FloatWeights {
+		half = 0.5 * math.Pow10(-g.WeightPrecision)
+	}
+	lo, hi := -half, half
+	if min == 0 {
+		lo = math.Inf(-1)
+	}
+	if max == 0 {
+		hi = math.Inf(1)
+	}
+	dist := g.WeightDistribution
+	switch {
+	case dist == nil || dist.Type == UniformDist:
+		if !g.FloatWeights {
+			// integer uniform distribution excludes max
+			if max == 0 {
+				return 0
+			}
+			return 1 / (max - min)
+		}
+		return math.Max(math.Min(hi, max)-math.Max(lo, min), 0) / (max - min)
+	case dist.Type == NormalDist:
+		cdf := func(x float64) float64 {
+			return 0.5 * math.Erfc((dist.Mean-x)/(dist.StdDev*math.Sqrt2))
+		}
+		return cdf(hi) - cdf(lo)
+	case dist.Type == ExponentialDist:
+		// tail is the probability that the value drawn above min is at least x
+		tail := func(x float64) float64 {
+			return math.Exp(-dist.Rate * math.Max(x, 0))
+		}
+		if !g.FloatWeights {
+			// integer values are rounded down
+			lo, hi = 0, 1
+			if max == 0 {
+				hi = math.Inf(1)
+			}
+		}
+		return tail(lo-min) - tail(hi-min)
+	case dist.Type == ZipfDist:
+		sum := 0.0
+		for k := 0.0; k <= max-min && k < zipfTerms; k++ {
+			sum += math.Pow(1+k, -dist.Exponent)
+		}
+		return math.Pow(1-min, -dist.Exponent) / sum
+	}
+	return 0
+}
+
+type WeightMode uint8
+
+const (
+	AnyWeights WeightMode = iota
+	DistinctWeights
+	UniqueShortestPaths
+	// NoNegativeCycles is rejected, every negative edge of undirected graph
+	// is a negative cycle when it is traversed there and back.
+	NoNegativeCycles
+)
+
+var weightModeToString = map[WeightMode]string{
+	AnyWeights:          "any",
+	DistinctWeights:     "distinct",
+	UniqueShortestPaths: "unique-shortest-paths",
+	NoNegativeCycles:    "no-negative-cycles"}
+
+var stringToWeightMode = map[string]WeightMode{
+	"any":                   AnyWeights,
+	"distinct":              DistinctWeights,
+	"unique-shortest-paths": UniqueShortestPaths,
+	"no-negative-cycles":    NoNegativeCycles}
+
+func (m WeightMode) String() string {
+	return weightModeToString[m]
+}
+
+func (m WeightMode) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('"')
+	buffer.WriteString(m.String())
+	buffer.WriteByte('"')
+	return buffer.Bytes(), nil
+}
+
+func (m *WeightMode) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	val, ok := stringToWeightMode[str]
+	if !ok {
+		return ErrInvalidWeightMode
+	}
+	if val == NoNegativeCycles {
+		return ErrNegativeCyclesMode
+	}
+	*m = val
+	return nil
+}
+
+// WeightBounds returns the smallest and the largest weight which can be drawn.
+func (g *GraphRequest) WeightBounds() (min, max int) {
+	if g.WeightDistribution == nil || g.WeightDistribution.Type != HistogramDist {
+		return g.WeightMin, g.WeightMax
+	}
+	first := true
+	for _, v := range g.WeightDistribution.Histogram {
+		if v.Weight <= 0 {
+			continue
+		}
+		if first || v.Value < min {
+			min = v.Value
+		}
+		if first || v.Value > max {
+			max = v.Value
+		}
+		first = false
+	}
+	return
+}
+
+func (g *GraphRequest) validWeightMode() bool {
+	min, _ := g.WeightBounds()
+	switch g.WeightMode {
+	case AnyWeights, DistinctWeights:
+		return true
+	case UniqueShortestPaths:
+		return min > 0
+	}
+	return false
+}
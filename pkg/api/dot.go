@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"io"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func (d *DotGraph) Extension() string {
@@ -16,12 +19,14 @@ func (d *DotGraph) Kind() string {
 }
 
 func (d *DotGraph) Convert(g generator.Graph) bool {
-	if g.Properties().Weighted() {
-		d.weighted = true
-	}
+	d.weighted = g.Properties().Weighted()
 	d.size = len(g.Edges())
-	d.edges = make(map[generator.WeightedEdge]int)
-	localWeights := g.Weights()
+	d.edges = make(map[generator.WeightedEdge]float64)
+	localWeights, precision := generator.FloatWeights(g)
+	d.precision = precision
+	d.labels = namedLabels(g)
+	d.vertexAttrs = vertexAttributes(g)
+	d.edgeAttrs = generator.EdgeAttributes(g)
 	localEdges := g.Edges()
 	for k := range localEdges {
 		for f, ok := range localEdges[k] {
@@ -48,25 +53,47 @@ func (d *DotGraph) Serialize(writer io.Writer) (io.Writer, error) {
 		return writer, err
 	}
 	foundVertices := make(map[int]bool)
-	for k, v := range d.edges {
+	edges := make([]generator.WeightedEdge, 0, len(d.edges))
+	for k := range d.edges {
+		edges = append(edges, k)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Left != edges[j].Left {
+			return edges[i].Left < edges[j].Left
+		}
+		return edges[i].Right < edges[j].Right
+	})
+	for _, k := range edges {
+		v := d.edges[k]
 		if v == 0 && !d.weighted {
 			continue
 		}
-		line := fmt.Sprintf("\t%d -- %d", k.Left, k.Right)
+		line := fmt.Sprintf("\t%s -- %s", d.node(k.Left), d.node(k.Right))
 		foundVertices[k.Left] = true
 		foundVertices[k.Right] = true
 		writer.Write([]byte(line))
+		attrs := make([]string, 0, 2)
 		if d.weighted {
-			weight := fmt.Sprintf(` [label="%d"]`, v)
-			writer.Write([]byte(weight))
+			attrs = append(attrs, fmt.Sprintf(`label="%s"`, strconv.FormatFloat(v, 'f', d.precision, 64)))
+		}
+		if len(d.edgeAttrs[k]) != 0 {
+			attrs = append(attrs, formatAttributes(d.edgeAttrs[k], ", ", dotAttribute))
+		}
+		if len(attrs) != 0 {
+			writer.Write([]byte(fmt.Sprintf(" [%s]", strings.Join(attrs, ", "))))
 		}
 		writer.Write([]byte("\n"))
 	}
 	for k := 0; k < d.size; k++ {
+		if k < len(d.vertexAttrs) && len(d.vertexAttrs[k]) != 0 {
+			line := fmt.Sprintf("\t%s [%s]\n", d.node(k), formatAttributes(d.vertexAttrs[k], ", ", dotAttribute))
+			writer.Write([]byte(line))
+			continue
+		}
 		if ok, ex := foundVertices[k]; ok && ex {
 			continue
 		}
-		line := fmt.Sprintf("\t%d\n", k)
+		line := fmt.Sprintf("\t%s\n", d.node(k))
 		writer.Write([]byte(line))
 	}
 	writer.Write([]byte("}\n"))
@@ -74,6 +101,19 @@ func (d *DotGraph) Serialize(writer io.Writer) (io.Writer, error) {
 	return writer, nil
 }
 
+// node returns ID of the node, named graphs use quoted names as IDs.
+func (d *DotGraph) node(k int) string {
+	if d.labels != nil {
+		return strconv.Quote(d.labels[k])
+	}
+	return strconv.Itoa(k)
+}
+
+// dotAttribute prints value of attribute as quoted DOT string.
+func dotAttribute(a generator.Attribute) string {
+	return strconv.Quote(a.String())
+}
+
 func (d *DotGraph) Bytes() []byte {
 	result := bytes.Buffer{}
 	d.Serialize(&result)
@@ -0,0 +1,334 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dotToken is lexical token of DOT, quoted marks IDs written as strings so they
+// can't be mistaken for keywords.
+type dotToken struct {
+	text   string
+	quoted bool
+}
+
+// dotLexer splits DOT source into tokens skipping comments, HTML strings and ports aren't supported.
+type dotLexer struct {
+	source string
+	tokens []dotToken
+}
+
+// identifier returns length of ID or numeral at the start of s, it ends before edge operator.
+func (l *dotLexer) identifier(s string) int {
+	end := 0
+	for end < len(s) {
+		r, size := utf8.DecodeRuneInString(s[end:])
+		if r != '_' && r != '.' && r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		if end > 0 && (strings.HasPrefix(s[end:], "--") || strings.HasPrefix(s[end:], "->")) {
+			break
+		}
+		end += size
+	}
+	return end
+}
+
+func (l *dotLexer) run() error {
+	s := l.source
+	for len(s) > 0 {
+		switch {
+		case s[0] == ' ' || s[0] == '\t' || s[0] == '\n' || s[0] == '\r':
+			s = s[1:]
+		case strings.HasPrefix(s, "//") || s[0] == '#':
+			end := strings.IndexByte(s, '\n')
+			if end < 0 {
+				end = len(s)
+			}
+			s = s[end:]
+		case strings.HasPrefix(s, "/*"):
+			end := strings.Index(s, "*/")
+			if end < 0 {
+				return importError("unterminated comment")
+			}
+			s = s[end+2:]
+		case strings.HasPrefix(s, "--") || strings.HasPrefix(s, "->"):
+			l.tokens = append(l.tokens, dotToken{text: s[:2]})
+			s = s[2:]
+		case strings.ContainsRune("{}[]=;,", rune(s[0])):
+			l.tokens = append(l.tokens, dotToken{text: s[:1]})
+			s = s[1:]
+		case s[0] == '"':
+			text, rest, err := l.quoted(s)
+			if err != nil {
+				return err
+			}
+			l.tokens = append(l.tokens, dotToken{text: text, quoted: true})
+			s = rest
+		default:
+			end := l.identifier(s)
+			if end == 0 {
+				return importError("unexpected character %q", s[0])
+			}
+			l.tokens = append(l.tokens, dotToken{text: s[:end]})
+			s = s[end:]
+		}
+	}
+	return nil
+}
+
+// quoted reads DOT string, only escaped quotes are unescaped as DOT keeps other escapes.
+func (l *dotLexer) quoted(s string) (string, string, error) {
+	builder := strings.Builder{}
+	for k := 1; k < len(s); k++ {
+		switch {
+		case s[k] == '\\' && k+1 < len(s) && s[k+1] == '"':
+			builder.WriteByte('"')
+			k++
+		case s[k] == '\\' && k+1 < len(s) && s[k+1] == '\\':
+			builder.WriteByte('\\')
+			k++
+		case s[k] == '"':
+			return builder.String(), s[k+1:], nil
+		default:
+			builder.WriteByte(s[k])
+		}
+	}
+	return "", "", importError("unterminated string")
+}
+
+// dotParser reads undirected DOT graph without subgraphs, edge weights are taken
+// from weight attribute or from numeric label, vertex weights from weight attribute of node.
+// Other attributes of nodes and edges are kept, labels are ignored.
+type dotParser struct {
+	tokens  []dotToken
+	pos     int
+	builder *graphBuilder
+}
+
+func (p *dotParser) peek() (dotToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return dotToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *dotParser) next() (dotToken, error) {
+	token, ok := p.peek()
+	if !ok {
+		return token, importError("unexpected end of graph")
+	}
+	p.pos++
+	return token, nil
+}
+
+func (p *dotParser) keyword(token dotToken, keyword string) bool {
+	return !token.quoted && strings.EqualFold(token.text, keyword)
+}
+
+var dotPunctuation = map[string]bool{"{": true, "}": true, "[": true, "]": true, "=": true, ";": true, ",": true, "--": true, "->": true}
+
+func (p *dotParser) punctuation(token dotToken) bool {
+	return !token.quoted && dotPunctuation[token.text]
+}
+
+func (p *dotParser) expect(text string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if token.quoted || token.text != text {
+		return importError("expected %q, found %q", text, token.text)
+	}
+	return nil
+}
+
+func (p *dotParser) id() (string, error) {
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if p.punctuation(token) {
+		return "", importError("expected ID, found %q", token.text)
+	}
+	for _, keyword := range []string{"subgraph", "graph", "digraph", "node", "edge", "strict"} {
+		if p.keyword(token, keyword) {
+			return "", importError("unsupported statement %q", token.text)
+		}
+	}
+	return token.text, nil
+}
+
+func (p *dotParser) graph() error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if p.keyword(token, "strict") {
+		if token, err = p.next(); err != nil {
+			return err
+		}
+	}
+	if p.keyword(token, "digraph") {
+		return importError("directed graphs aren't supported")
+	}
+	if !p.keyword(token, "graph") {
+		return importError("expected graph, found %q", token.text)
+	}
+	if token, err = p.next(); err != nil {
+		return err
+	}
+	if !p.punctuation(token) {
+		if token, err = p.next(); err != nil {
+			return err
+		}
+	}
+	if token.text != "{" || token.quoted {
+		return importError("expected {, found %q", token.text)
+	}
+	for {
+		token, ok := p.peek()
+		switch {
+		case !ok:
+			return importError("unexpected end of graph")
+		case token.text == "}" && !token.quoted:
+			p.pos++
+			if _, ok := p.peek(); ok {
+				return importError("only one graph can be imported")
+			}
+			return nil
+		case token.text == ";" && !token.quoted:
+			p.pos++
+		default:
+			if err := p.statement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *dotParser) statement() error {
+	token, _ := p.peek()
+	if p.keyword(token, "graph") || p.keyword(token, "node") || p.keyword(token, "edge") {
+		p.pos++
+		_, err := p.attributes()
+		return err
+	}
+	first, err := p.id()
+	if err != nil {
+		return err
+	}
+	if token, ok := p.peek(); ok && token.text == "=" && !token.quoted {
+		p.pos++
+		_, err = p.id()
+		return err
+	}
+
+	nodes := []string{first}
+	for {
+		token, ok := p.peek()
+		if !ok || token.quoted || (token.text != "--" && token.text != "->") {
+			break
+		}
+		if token.text == "->" {
+			return importError("directed edges aren't supported")
+		}
+		p.pos++
+		node, err := p.id()
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+	}
+	attributes, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 1 {
+		p.builder.addNode(first)
+		for name, value := range attributes {
+			switch name {
+			case "weight":
+				err = p.builder.setVertexWeight(first, value)
+			case "label":
+			default:
+				p.builder.setVertexAttribute(first, name, parseAttribute(value))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	weight := attributes["weight"]
+	if weight == "" {
+		if _, err := strconv.ParseFloat(attributes["label"], 64); err == nil {
+			weight = attributes["label"]
+		}
+	}
+	for k := 1; k < len(nodes); k++ {
+		if err := p.builder.addEdge(nodes[k-1], nodes[k], weight); err != nil {
+			return err
+		}
+		for name, value := range attributes {
+			if !generator.ReservedAttributes[name] {
+				p.builder.setEdgeAttribute(nodes[k-1], nodes[k], name, parseAttribute(value))
+			}
+		}
+	}
+	return nil
+}
+
+// attributes reads optional attribute lists following the statement.
+func (p *dotParser) attributes() (map[string]string, error) {
+	result := make(map[string]string)
+	for {
+		token, ok := p.peek()
+		if !ok || token.quoted || token.text != "[" {
+			return result, nil
+		}
+		p.pos++
+		for {
+			token, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			if !token.quoted && (token.text == "," || token.text == ";") {
+				continue
+			}
+			if !token.quoted && token.text == "]" {
+				break
+			}
+			if p.punctuation(token) {
+				return nil, importError("expected attribute, found %q", token.text)
+			}
+			if err = p.expect("="); err != nil {
+				return nil, err
+			}
+			value, err := p.next()
+			if err != nil {
+				return nil, err
+			}
+			result[token.text] = value.text
+		}
+	}
+}
+
+// parseDot reads strict subset of DOT language, undirected graph with node and edge
+// statements. Default attribute statements and graph attributes are ignored.
+func parseDot(data []byte, _ url.Values) (generator.Graph, error) {
+	lexer := dotLexer{source: string(data)}
+	if err := lexer.run(); err != nil {
+		return nil, err
+	}
+	parser := dotParser{tokens: lexer.tokens, builder: newGraphBuilder()}
+	if err := parser.graph(); err != nil {
+		return nil, err
+	}
+	return parser.builder.build()
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"sort"
+	"strconv"
+)
+
+// attributeDecl declares attribute used by some vertex or edge, attributes
+// stored with different kinds are declared as strings.
+type attributeDecl struct {
+	Name string
+	Kind generator.AttributeKind
+}
+
+// exportData is the graph prepared for export into formats with declared attributes,
+// edges are sorted and listed only once with the smaller node first.
+type exportData struct {
+	labels          []string
+	named           bool
+	weighted        bool
+	float           bool
+	precision       int
+	edges           []generator.WeightedEdge
+	weights         map[generator.WeightedEdge]float64
+	vertexAttrs     []generator.Attributes
+	edgeAttrs       map[generator.WeightedEdge]generator.Attributes
+	vertexWeights   []float64
+	vertexPrecision int
+	vertexDecls     []attributeDecl
+	edgeDecls       []attributeDecl
+}
+
+func newExportData(g generator.Graph) exportData {
+	weights, precision := generator.FloatWeights(g)
+	data := exportData{
+		labels:      nodeLabels(g),
+		named:       g.Properties().Named(),
+		weighted:    g.Properties().Weighted(),
+		float:       g.Properties().Float(),
+		precision:   precision,
+		edges:       sortedEdges(g.Edges()),
+		weights:     weights,
+		vertexAttrs: vertexAttributes(g),
+		edgeAttrs:   generator.EdgeAttributes(g),
+	}
+	data.vertexWeights, data.vertexPrecision = generator.VertexWeights(g)
+	data.vertexDecls = declareAttributes(data.vertexAttrs)
+	edgeAttrs := make([]generator.Attributes, 0, len(data.edgeAttrs))
+	for _, e := range data.edges {
+		edgeAttrs = append(edgeAttrs, data.edgeAttrs[e])
+	}
+	data.edgeDecls = declareAttributes(edgeAttrs)
+	return data
+}
+
+// sortedEdges lists every edge once ordered by its smaller and then larger node.
+func sortedEdges(edges []map[int]bool) []generator.WeightedEdge {
+	result := make([]generator.WeightedEdge, 0)
+	for k := range edges {
+		for j, ok := range edges[k] {
+			if ok && j > k {
+				result = append(result, generator.CreateEdge(k, j))
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Left != result[j].Left {
+			return result[i].Left < result[j].Left
+		}
+		return result[i].Right < result[j].Right
+	})
+	return result
+}
+
+// declareAttributes collects names and kinds of all the attributes sorted by name.
+func declareAttributes(attributes []generator.Attributes) []attributeDecl {
+	kinds := make(map[string]generator.AttributeKind)
+	for _, attrs := range attributes {
+		for name, v := range attrs {
+			if kind, ok := kinds[name]; ok && kind != v.Kind {
+				kinds[name] = generator.StringKind
+				continue
+			}
+			kinds[name] = v.Kind
+		}
+	}
+	result := make([]attributeDecl, 0, len(kinds))
+	for name, kind := range kinds {
+		result = append(result, attributeDecl{Name: name, Kind: kind})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// weight formats weight of the edge with precision of the graph.
+func (d *exportData) weight(e generator.WeightedEdge) string {
+	return strconv.FormatFloat(d.weights[e], 'f', d.precision, 64)
+}
+
+// vertexWeight formats weight of the vertex with precision of vertex weights.
+func (d *exportData) vertexWeight(k int) string {
+	return strconv.FormatFloat(d.vertexWeights[k], 'f', d.vertexPrecision, 64)
+}
+
+// weightKind is kind of the weights of edges.
+func (d *exportData) weightKind() generator.AttributeKind {
+	if d.float {
+		return generator.FloatKind
+	}
+	return generator.IntKind
+}
+
+// vertexAttr returns attribute of the vertex, if it has one.
+func (d *exportData) vertexAttr(k int, name string) (generator.Attribute, bool) {
+	if k >= len(d.vertexAttrs) {
+		return generator.Attribute{}, false
+	}
+	v, ok := d.vertexAttrs[k][name]
+	return v, ok
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"strconv"
+)
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfValues struct {
+	Values []gexfValue `xml:"attvalue"`
+}
+
+type gexfNode struct {
+	ID     string      `xml:"id,attr"`
+	Label  string      `xml:"label,attr"`
+	Values *gexfValues `xml:"attvalues,omitempty"`
+}
+
+type gexfEdge struct {
+	ID     string      `xml:"id,attr"`
+	Source string      `xml:"source,attr"`
+	Target string      `xml:"target,attr"`
+	Weight string      `xml:"weight,attr,omitempty"`
+	Values *gexfValues `xml:"attvalues,omitempty"`
+}
+
+// addGEXFValue appends value of attribute, the list of values is created by the first one.
+func addGEXFValue(values **gexfValues, value gexfValue) {
+	if *values == nil {
+		*values = &gexfValues{}
+	}
+	(*values).Values = append((*values).Values, value)
+}
+
+type gexfDocument struct {
+	XMLName xml.Name `xml:"gexf"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Version string   `xml:"version,attr"`
+	Graph   struct {
+		DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+		Mode            string           `xml:"mode,attr"`
+		Attributes      []gexfAttributes `xml:"attributes"`
+		Nodes           []gexfNode       `xml:"nodes>node"`
+		Edges           []gexfEdge       `xml:"edges>edge"`
+	} `xml:"graph"`
+}
+
+var gexfTypes = map[generator.AttributeKind]string{
+	generator.StringKind: "string",
+	generator.IntKind:    "integer",
+	generator.FloatKind:  "double"}
+
+func (g *GEXFGraph) Extension() string {
+	return "gexf"
+}
+
+func (g *GEXFGraph) Kind() string {
+	return "gexf"
+}
+
+func (g *GEXFGraph) ContentType() string {
+	return "application/gexf+xml"
+}
+
+func (g *GEXFGraph) Convert(graph generator.Graph) bool {
+	g.data = newExportData(graph)
+	return true
+}
+
+// gexfDeclarations declares the attributes of one class, they are identified by their index.
+func gexfDeclarations(class string, decls []attributeDecl) []gexfAttributes {
+	if len(decls) == 0 {
+		return nil
+	}
+	result := gexfAttributes{Class: class}
+	for k, v := range decls {
+		result.Attributes = append(result.Attributes, gexfAttribute{ID: strconv.Itoa(k), Title: v.Name, Type: gexfTypes[v.Kind]})
+	}
+	return []gexfAttributes{result}
+}
+
+// document builds the GEXF document, weights are stored in the native weight
+// attribute of edges and attributes are declared per class.
+func (g *GEXFGraph) document() gexfDocument {
+	d := &g.data
+	doc := gexfDocument{Xmlns: "http://gexf.net/1.3", Version: "1.3"}
+	doc.Graph.DefaultEdgeType = "undirected"
+	doc.Graph.Mode = "static"
+	doc.Graph.Attributes = append(gexfDeclarations("node", d.vertexDecls), gexfDeclarations("edge", d.edgeDecls)...)
+
+	doc.Graph.Nodes = make([]gexfNode, len(d.labels))
+	for k := range d.labels {
+		node := gexfNode{ID: strconv.Itoa(k), Label: d.labels[k]}
+		for j, decl := range d.vertexDecls {
+			if v, ok := d.vertexAttr(k, decl.Name); ok {
+				addGEXFValue(&node.Values, gexfValue{For: strconv.Itoa(j), Value: v.String()})
+			}
+		}
+		doc.Graph.Nodes[k] = node
+	}
+	doc.Graph.Edges = make([]gexfEdge, len(d.edges))
+	for k, e := range d.edges {
+		edge := gexfEdge{ID: strconv.Itoa(k), Source: strconv.Itoa(e.Left), Target: strconv.Itoa(e.Right)}
+		if d.weighted {
+			edge.Weight = d.weight(e)
+		}
+		for j, decl := range d.edgeDecls {
+			if v, ok := d.edgeAttrs[e][decl.Name]; ok {
+				addGEXFValue(&edge.Values, gexfValue{For: strconv.Itoa(j), Value: v.String()})
+			}
+		}
+		doc.Graph.Edges[k] = edge
+	}
+	return doc
+}
+
+func (g *GEXFGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	return writer, writeXML(writer, g.document())
+}
+
+func (g *GEXFGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	g.Serialize(&buffer)
+	return buffer.Bytes()
+}
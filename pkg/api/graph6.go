@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"sort"
+)
+
+// graph6Size encodes number of nodes as defined by the graph6 format.
+func graph6Size(n int) []byte {
+	switch {
+	case n <= 62:
+		return []byte{byte(n + 63)}
+	case n <= 258047:
+		return []byte{126, byte(n>>12&63 + 63), byte(n>>6&63 + 63), byte(n&63 + 63)}
+	}
+	result := []byte{126, 126}
+	for shift := 30; shift >= 0; shift -= 6 {
+		result = append(result, byte(n>>shift&63+63))
+	}
+	return result
+}
+
+// bitWriter packs bits into printable bytes by six bits, the first bit is the most significant.
+type bitWriter struct {
+	data  []byte
+	count int
+}
+
+func (b *bitWriter) write(bit bool) {
+	if b.count%6 == 0 {
+		b.data = append(b.data, 0)
+	}
+	if bit {
+		b.data[len(b.data)-1] |= 1 << (5 - b.count%6)
+	}
+	b.count++
+}
+
+// writeNumber writes k lowest bits of x.
+func (b *bitWriter) writeNumber(x, k int) {
+	for i := k - 1; i >= 0; i-- {
+		b.write(x>>i&1 == 1)
+	}
+}
+
+// padding returns number of bits missing to the whole byte.
+func (b *bitWriter) padding() int {
+	return (6 - b.count%6) % 6
+}
+
+func (b *bitWriter) bytes() []byte {
+	result := make([]byte, len(b.data))
+	for k, v := range b.data {
+		result[k] = v + 63
+	}
+	return result
+}
+
+func (g *Graph6Graph) Extension() string {
+	return "g6"
+}
+
+func (g *Graph6Graph) Kind() string {
+	return "graph6"
+}
+
+func (g *Graph6Graph) ContentType() string {
+	return "text/plain"
+}
+
+// Concatenable is true, every graph is written as one line.
+func (g *Graph6Graph) Concatenable() bool {
+	return true
+}
+
+// Convert encodes upper triangle of adjacency matrix column by column,
+// weights and names aren't part of the format.
+func (g *Graph6Graph) Convert(graph generator.Graph) bool {
+	edges := graph.Edges()
+	bits := bitWriter{}
+	for j := 1; j < len(edges); j++ {
+		for i := 0; i < j; i++ {
+			bits.write(edges[i][j])
+		}
+	}
+	g.line = append(graph6Size(len(edges)), bits.bytes()...)
+	return true
+}
+
+func (g *Graph6Graph) Serialize(writer io.Writer) (io.Writer, error) {
+	_, err := writer.Write(append(g.line, '\n'))
+	return writer, err
+}
+
+func (g *Graph6Graph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	g.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func (s *Sparse6Graph) Extension() string {
+	return "s6"
+}
+
+func (s *Sparse6Graph) Kind() string {
+	return "sparse6"
+}
+
+func (s *Sparse6Graph) ContentType() string {
+	return "text/plain"
+}
+
+// Concatenable is true, every graph is written as one line.
+func (s *Sparse6Graph) Concatenable() bool {
+	return true
+}
+
+// Convert encodes the list of edges sorted by larger node, every edge is written
+// as a flag whether the larger node is incremented followed by the smaller node,
+// or as a jump to the larger node when it's further.
+func (s *Sparse6Graph) Convert(graph generator.Graph) bool {
+	n := len(graph.Edges())
+	k := 1
+	for 1<<k < n {
+		k++
+	}
+	edges := sortedEdges(graph.Edges())
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Right < edges[j].Right
+	})
+
+	bits := bitWriter{}
+	current := 0
+	for _, e := range edges {
+		switch e.Right {
+		case current:
+			bits.write(false)
+		case current + 1:
+			current++
+			bits.write(true)
+		default:
+			current = e.Right
+			bits.write(true)
+			bits.writeNumber(e.Right, k)
+			bits.write(false)
+		}
+		bits.writeNumber(e.Left, k)
+	}
+	if k < 6 && n == 1<<k && bits.padding() >= k && current < n-1 {
+		// padding by ones would be read as edge to the last node
+		bits.write(false)
+	}
+	for pad := bits.padding(); pad > 0; pad-- {
+		bits.write(true)
+	}
+	s.line = append(append([]byte{':'}, graph6Size(n)...), bits.bytes()...)
+	return true
+}
+
+func (s *Sparse6Graph) Serialize(writer io.Writer) (io.Writer, error) {
+	_, err := writer.Write(append(s.line, '\n'))
+	return writer, err
+}
+
+func (s *Sparse6Graph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	s.Serialize(&buffer)
+	return buffer.Bytes()
+}
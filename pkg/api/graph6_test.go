@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func graphFromEdges(n int, edges [][2]int) generator.SimpleGraph {
+	result := generator.SimpleGraph{Size: n, EdgesMap: make([]map[int]bool, n)}
+	for k := range result.EdgesMap {
+		result.EdgesMap[k] = make(map[int]bool)
+	}
+	for _, e := range edges {
+		result.EdgesMap[e[0]][e[1]] = true
+		result.EdgesMap[e[1]][e[0]] = true
+	}
+	return result
+}
+
+func TestGraph6(t *testing.T) {
+	translator := &Graph6Graph{}
+	assert.True(t, translator.Convert(graphFromEdges(2, [][2]int{{0, 1}})))
+	assert.Equal(t, "A_\n", string(translator.Bytes()))
+
+	petersen := graphFromEdges(10, [][2]int{
+		{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+		{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+		{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+	})
+	translator.Convert(petersen)
+	assert.Equal(t, "IheA@GUAo\n", string(translator.Bytes()))
+
+	// examples from the definition of the format
+	assert.Equal(t, []byte{93}, graph6Size(30))
+	assert.Equal(t, []byte{126, 66, 63, 120}, graph6Size(12345))
+	assert.Equal(t, []byte{126, 126, 63, 90, 90, 90, 90, 90}, graph6Size(460175067))
+}
+
+func TestSparse6(t *testing.T) {
+	translator := &Sparse6Graph{}
+	assert.True(t, translator.Convert(graphFromEdges(2, [][2]int{{0, 1}})))
+	assert.Equal(t, ":An\n", string(translator.Bytes()))
+
+	translator.Convert(graphFromEdges(7, [][2]int{{0, 1}, {0, 2}, {1, 2}, {5, 6}}))
+	assert.Equal(t, ":Fa@x^\n", string(translator.Bytes()))
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+)
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+var graphMLTypes = map[generator.AttributeKind]string{
+	generator.StringKind: "string",
+	generator.IntKind:    "int",
+	generator.FloatKind:  "double"}
+
+func (g *GraphMLGraph) Extension() string {
+	return "graphml"
+}
+
+func (g *GraphMLGraph) Kind() string {
+	return "graphml"
+}
+
+func (g *GraphMLGraph) ContentType() string {
+	return "application/graphml+xml"
+}
+
+func (g *GraphMLGraph) Convert(graph generator.Graph) bool {
+	g.data = newExportData(graph)
+	return true
+}
+
+// document builds the GraphML document, node labels and edge weights are
+// stored as data with keys label and weight, attributes follow with own keys.
+func (g *GraphMLGraph) document() graphMLDocument {
+	d := &g.data
+	doc := graphMLDocument{Xmlns: "http://graphml.graphdrawing.org/xmlns"}
+	doc.Graph.ID = "G"
+	doc.Graph.EdgeDefault = "undirected"
+	if d.named {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "label", For: "node", Name: "label", Type: "string"})
+	}
+	if d.weighted {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "weight", For: "edge", Name: "weight", Type: graphMLTypes[d.weightKind()]})
+	}
+	for k, v := range d.vertexDecls {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: fmt.Sprintf("v%d", k), For: "node", Name: v.Name, Type: graphMLTypes[v.Kind]})
+	}
+	for k, v := range d.edgeDecls {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: fmt.Sprintf("e%d", k), For: "edge", Name: v.Name, Type: graphMLTypes[v.Kind]})
+	}
+
+	doc.Graph.Nodes = make([]graphMLNode, len(d.labels))
+	for k := range d.labels {
+		node := graphMLNode{ID: fmt.Sprintf("n%d", k)}
+		if d.named {
+			node.Data = append(node.Data, graphMLData{Key: "label", Value: d.labels[k]})
+		}
+		for j, decl := range d.vertexDecls {
+			if v, ok := d.vertexAttr(k, decl.Name); ok {
+				node.Data = append(node.Data, graphMLData{Key: fmt.Sprintf("v%d", j), Value: v.String()})
+			}
+		}
+		doc.Graph.Nodes[k] = node
+	}
+	doc.Graph.Edges = make([]graphMLEdge, len(d.edges))
+	for k, e := range d.edges {
+		edge := graphMLEdge{ID: fmt.Sprintf("e%d", k), Source: fmt.Sprintf("n%d", e.Left), Target: fmt.Sprintf("n%d", e.Right)}
+		if d.weighted {
+			edge.Data = append(edge.Data, graphMLData{Key: "weight", Value: d.weight(e)})
+		}
+		for j, decl := range d.edgeDecls {
+			if v, ok := d.edgeAttrs[e][decl.Name]; ok {
+				edge.Data = append(edge.Data, graphMLData{Key: fmt.Sprintf("e%d", j), Value: v.String()})
+			}
+		}
+		doc.Graph.Edges[k] = edge
+	}
+	return doc
+}
+
+func (g *GraphMLGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	return writer, writeXML(writer, g.document())
+}
+
+func (g *GraphMLGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	g.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+// writeXML writes indented XML document with the declaration.
+func writeXML(writer io.Writer, document any) error {
+	_, err := writer.Write([]byte(xml.Header))
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(document)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write([]byte{'\n'})
+	return err
+}
@@ -0,0 +1,652 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrImportNotSupported = errors.New("import of the format is not supported")
+	ErrInvalidGraphData   = errors.New("graph couldn't be parsed")
+)
+
+// GraphParser reads graph of one format, options are the query parameters of the import.
+type GraphParser func(data []byte, options url.Values) (generator.Graph, error)
+
+var graphParsers = map[string]GraphParser{
+	"matrix":   parseMatrix,
+	"dot":      parseDot,
+	"edgelist": parseEdgeList,
+	"graph6":   parseGraph6,
+	"JSON":     parseJSON,
+}
+
+// ImportFormats lists names of formats graphs can be imported from.
+func ImportFormats() []string {
+	result := make([]string, 0, len(graphParsers))
+	for k := range graphParsers {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ParseGraph reads graph of format given by its name or extension.
+func ParseGraph(format string, data []byte, options url.Values) (generator.Graph, error) {
+	parser, ok := graphParsers[formatName(format)]
+	if !ok {
+		return nil, ErrImportNotSupported
+	}
+	return parser(data, options)
+}
+
+// ImportedRequest describes imported graph by request of Imported type.
+func ImportedRequest(g generator.Graph) GraphRequest {
+	_, precision := generator.FloatWeights(g)
+	return GraphRequest{
+		Type:            Imported,
+		Nodes:           len(g.Edges()),
+		Weighted:        g.Properties().Weighted(),
+		FloatWeights:    g.Properties().Float(),
+		WeightPrecision: precision,
+		VertexWeighted:  g.Properties().VertexWeighted(),
+	}
+}
+
+func importError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidGraphData, fmt.Sprintf(format, args...))
+}
+
+// graphBuilder collects nodes and edges of parsed graph. Nodes are referenced by names,
+// graphs whose names are all non-negative integers use them as indices of the nodes.
+// Vertex weights and attributes are optional, vertices without weight get zero.
+type graphBuilder struct {
+	names          []string
+	index          map[string]int
+	size           int
+	named          bool
+	edges          map[[2]string]float64
+	order          [][2]string
+	weighted       bool
+	float          bool
+	decimals       int
+	vertexWeights  map[string]float64
+	vertexDecimals int
+	vertexAttrs    map[string]generator.Attributes
+	edgeAttrs      map[[2]string]generator.Attributes
+}
+
+func newGraphBuilder() *graphBuilder {
+	return &graphBuilder{index: make(map[string]int), edges: make(map[[2]string]float64)}
+}
+
+func (b *graphBuilder) addNode(name string) {
+	if _, ok := b.index[name]; !ok {
+		b.index[name] = len(b.names)
+		b.names = append(b.names, name)
+	}
+}
+
+// addEdge adds edge between the nodes, weight is empty for unweighted edges.
+// Repeated edges are merged and keep the last weight.
+func (b *graphBuilder) addEdge(left, right, weight string) error {
+	if left == right {
+		return importError("loop on node %q", left)
+	}
+	b.addNode(left)
+	b.addNode(right)
+	value := 1.0
+	if weight != "" {
+		var err error
+		value, err = b.parseWeight(weight)
+		if err != nil {
+			return err
+		}
+	}
+	key := b.edgeKey(left, right)
+	if _, ok := b.edges[key]; !ok {
+		b.order = append(b.order, key)
+	}
+	b.edges[key] = value
+	return nil
+}
+
+// edgeKey orders the nodes of edge by their indices.
+func (b *graphBuilder) edgeKey(left, right string) [2]string {
+	if b.index[left] > b.index[right] {
+		return [2]string{right, left}
+	}
+	return [2]string{left, right}
+}
+
+// setVertexWeight sets weight of the node, vertex weights are rounded to the largest
+// number of decimals found.
+func (b *graphBuilder) setVertexWeight(name, weight string) error {
+	value, decimals, err := parseDecimal(weight)
+	if err != nil {
+		return err
+	}
+	b.addNode(name)
+	if b.vertexWeights == nil {
+		b.vertexWeights = make(map[string]float64)
+	}
+	b.vertexWeights[name] = value
+	if decimals > b.vertexDecimals {
+		b.vertexDecimals = decimals
+	}
+	return nil
+}
+
+func (b *graphBuilder) setVertexAttribute(node, name string, value generator.Attribute) {
+	b.addNode(node)
+	if b.vertexAttrs == nil {
+		b.vertexAttrs = make(map[string]generator.Attributes)
+	}
+	if b.vertexAttrs[node] == nil {
+		b.vertexAttrs[node] = generator.Attributes{}
+	}
+	b.vertexAttrs[node][name] = value
+}
+
+// setEdgeAttribute sets attribute of edge which was already added.
+func (b *graphBuilder) setEdgeAttribute(left, right, name string, value generator.Attribute) {
+	key := b.edgeKey(left, right)
+	if b.edgeAttrs == nil {
+		b.edgeAttrs = make(map[[2]string]generator.Attributes)
+	}
+	if b.edgeAttrs[key] == nil {
+		b.edgeAttrs[key] = generator.Attributes{}
+	}
+	b.edgeAttrs[key][name] = value
+}
+
+// parseDecimal reads finite number together with the number of digits after its decimal point.
+func parseDecimal(text string) (float64, int, error) {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, 0, importError("invalid weight %q", text)
+	}
+	_, fraction, _ := strings.Cut(text, ".")
+	digits := len(fraction) - len(strings.TrimLeft(fraction, "0123456789"))
+	if digits > MaxWeightPrecision {
+		digits = MaxWeightPrecision
+	}
+	return value, digits, nil
+}
+
+// parseWeight reads weight, weights written with decimal point make the graph float weighted
+// with the largest number of decimals found.
+func (b *graphBuilder) parseWeight(weight string) (float64, error) {
+	value, digits, err := parseDecimal(weight)
+	if err != nil {
+		return 0, err
+	}
+	b.weighted = true
+	if strings.Contains(weight, ".") || value != math.Trunc(value) {
+		b.float = true
+	}
+	if digits > b.decimals {
+		b.decimals = digits
+	}
+	return value, nil
+}
+
+// parseAttribute types attribute value written as text, numbers become numeric attributes.
+func parseAttribute(text string) generator.Attribute {
+	if v, err := strconv.Atoi(text); err == nil {
+		return generator.IntAttr(v)
+	}
+	if v, err := strconv.ParseFloat(text, 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		return generator.FloatAttr(v)
+	}
+	return generator.StringAttr(text)
+}
+
+// jsonAttribute types attribute value decoded from JSON, only strings and numbers are supported.
+func jsonAttribute(value any) (generator.Attribute, bool) {
+	switch v := value.(type) {
+	case string:
+		return generator.StringAttr(v), true
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return generator.IntAttr(int(v)), true
+		}
+		return generator.FloatAttr(v), true
+	}
+	return generator.Attribute{}, false
+}
+
+// numericNames checks whether all names are canonical non-negative integers.
+func (b *graphBuilder) numericNames() bool {
+	for _, name := range b.names {
+		v, err := strconv.Atoi(name)
+		if err != nil || v < 0 || strconv.Itoa(v) != name {
+			return false
+		}
+	}
+	return true
+}
+
+// checkImportSize rejects graphs larger than permitted before their nodes are allocated,
+// names and headers of few bytes can declare huge number of nodes.
+func checkImportSize(size int) error {
+	if max := configuration.Default().MaxNodes; size > max {
+		return importError("graph has %d nodes, at most %d are permitted", size, max)
+	}
+	return nil
+}
+
+func (b *graphBuilder) build() (generator.Graph, error) {
+	positions := b.index
+	size := len(b.names)
+	numeric := !b.named && b.numericNames()
+	if numeric {
+		positions = make(map[string]int, len(b.names))
+		for _, name := range b.names {
+			v, _ := strconv.Atoi(name)
+			positions[name] = v
+			if v >= size {
+				size = v + 1
+			}
+		}
+	}
+	if b.size > size {
+		if !numeric {
+			return nil, importError("graph has %d nodes, found names of %d", b.size, size)
+		}
+		size = b.size
+	}
+	if size == 0 {
+		return nil, importError("graph has no nodes")
+	}
+	if err := checkImportSize(size); err != nil {
+		return nil, err
+	}
+
+	simple := generator.SimpleGraph{Size: size, EdgesMap: make([]map[int]bool, size)}
+	for k := range simple.EdgesMap {
+		simple.EdgesMap[k] = make(map[int]bool)
+	}
+	weights := make(map[generator.WeightedEdge]float64, len(b.order))
+	for _, key := range b.order {
+		left, right := positions[key[0]], positions[key[1]]
+		simple.EdgesMap[left][right] = true
+		simple.EdgesMap[right][left] = true
+		weights[generator.CreateEdge(left, right)] = b.edges[key]
+	}
+
+	var result generator.Graph = simple
+	switch {
+	case b.weighted && b.float:
+		result = generator.FloatWeightedGraph{ParentGraph: simple, WeightsMap: weights, Decimals: b.decimals}
+	case b.weighted:
+		intWeights := make(map[generator.WeightedEdge]int, len(weights))
+		for k, v := range weights {
+			intWeights[k] = int(v)
+		}
+		result = generator.WeightedGraph{ParentGraph: simple, WeightsMap: intWeights}
+	}
+	if b.vertexWeights != nil || b.vertexAttrs != nil || b.edgeAttrs != nil {
+		attributed := b.attributed(result, positions, size)
+		if err := attributed.Validate(); err != nil {
+			return nil, importError("%s", err.Error())
+		}
+		result = attributed
+	}
+	if !numeric {
+		result = generator.NamedGraph{ParentGraph: result, VertexNames: b.names}
+	}
+	return result, nil
+}
+
+// attributed wraps the graph with vertex weights and attributes of the builder.
+func (b *graphBuilder) attributed(parent generator.Graph, positions map[string]int, size int) generator.AttributedGraph {
+	result := generator.AttributedGraph{ParentGraph: parent, WeightDecimals: b.vertexDecimals}
+	if b.vertexWeights != nil {
+		result.VertexWeights = make([]float64, size)
+		for name, v := range b.vertexWeights {
+			result.VertexWeights[positions[name]] = v
+		}
+	}
+	if b.vertexAttrs != nil {
+		result.VertexAttributes = make([]generator.Attributes, size)
+		for k := range result.VertexAttributes {
+			result.VertexAttributes[k] = generator.Attributes{}
+		}
+		for name, attributes := range b.vertexAttrs {
+			result.VertexAttributes[positions[name]] = attributes
+		}
+	}
+	if b.edgeAttrs != nil {
+		result.EdgeAttributes = make(map[generator.WeightedEdge]generator.Attributes, len(b.edgeAttrs))
+		for key, attributes := range b.edgeAttrs {
+			result.EdgeAttributes[generator.CreateEdge(positions[key[0]], positions[key[1]])] = attributes
+		}
+	}
+	return result
+}
+
+// splitFields splits line into whitespace separated fields, fields may be double-quoted.
+func splitFields(line string) ([]string, error) {
+	var result []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if line[0] != '"' {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			result = append(result, line[:end])
+			line = line[end:]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, importError("unterminated string in %q", line)
+		}
+		field, _ := strconv.Unquote(quoted)
+		result = append(result, field)
+		line = line[len(quoted):]
+	}
+	return result, nil
+}
+
+// parseMatrix reads adjacency matrix as written by MatrixGraph, non-zero entries are edges
+// and entries other than one are weights. The attribute section following the matrix is skipped.
+func parseMatrix(data []byte, _ url.Values) (generator.Graph, error) {
+	builder := newGraphBuilder()
+	var labels []string
+	var rows [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if len(rows) != 0 {
+				break
+			}
+			continue
+		}
+		if header, ok := strings.CutPrefix(line, "#"); ok {
+			if len(rows) == 0 && labels == nil {
+				fields, err := splitFields(header)
+				if err != nil {
+					return nil, err
+				}
+				labels = fields
+			}
+			continue
+		}
+		rows = append(rows, strings.Fields(line))
+	}
+	if labels != nil && len(labels) != len(rows) {
+		labels = nil
+	}
+
+	name := func(k int) string {
+		if labels != nil {
+			return labels[k]
+		}
+		return strconv.Itoa(k)
+	}
+	for k := range rows {
+		if len(rows[k]) != len(rows) {
+			return nil, importError("row %d has %d entries, expected %d", k+1, len(rows[k]), len(rows))
+		}
+		if _, ok := builder.index[name(k)]; ok {
+			return nil, importError("duplicate node label %q", name(k))
+		}
+		builder.addNode(name(k))
+	}
+	builder.named = labels != nil
+	builder.size = len(rows)
+
+	weighted := false
+	for k := range rows {
+		for j := k; j < len(rows); j++ {
+			if rows[k][j] != rows[j][k] {
+				value, err1 := strconv.ParseFloat(rows[k][j], 64)
+				other, err2 := strconv.ParseFloat(rows[j][k], 64)
+				if err1 != nil || err2 != nil || value != other {
+					return nil, importError("matrix isn't symmetric at %d, %d", k+1, j+1)
+				}
+			}
+			value, err := strconv.ParseFloat(rows[k][j], 64)
+			if err != nil {
+				return nil, importError("invalid entry %q", rows[k][j])
+			}
+			if value == 0 {
+				continue
+			}
+			if k == j {
+				return nil, importError("loop on node %d", k+1)
+			}
+			weighted = weighted || value != 1 || strings.Contains(rows[k][j], ".")
+		}
+	}
+	for k := range rows {
+		for j := k + 1; j < len(rows); j++ {
+			if value, _ := strconv.ParseFloat(rows[k][j], 64); value == 0 {
+				continue
+			}
+			weight := ""
+			if weighted {
+				weight = rows[k][j]
+			}
+			if err := builder.addEdge(name(k), name(j), weight); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return builder.build()
+}
+
+// parseEdgeList reads one edge per line as written by EdgeListGraph, header option
+// tells the first line contains number of nodes and edges.
+func parseEdgeList(data []byte, options url.Values) (generator.Graph, error) {
+	builder := newGraphBuilder()
+	header := options.Get("header") == "true"
+	expectedEdges := -1
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '%' {
+			continue
+		}
+		fields, err := splitFields(line)
+		if err != nil {
+			return nil, err
+		}
+		if header {
+			header = false
+			if len(fields) != 2 {
+				return nil, importError("header must contain number of nodes and edges")
+			}
+			builder.size, err = strconv.Atoi(fields[0])
+			if err == nil {
+				expectedEdges, err = strconv.Atoi(fields[1])
+			}
+			if err != nil || builder.size < 0 || expectedEdges < 0 {
+				return nil, importError("invalid header %q", line)
+			}
+			if err = checkImportSize(builder.size); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		lines++
+		switch len(fields) {
+		case 2:
+			err = builder.addEdge(fields[0], fields[1], "")
+		case 3:
+			err = builder.addEdge(fields[0], fields[1], fields[2])
+		default:
+			err = importError("line %q isn't an edge", line)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if expectedEdges >= 0 && expectedEdges != lines {
+		return nil, importError("header declares %d edges, found %d", expectedEdges, lines)
+	}
+	return builder.build()
+}
+
+// maxGraph6Nodes bounds size of parsed graph6 graphs, so the size of data can't overflow.
+const maxGraph6Nodes = 1 << 20
+
+// parseGraph6 reads one graph in graph6 format, the optional >>graph6<< header is skipped.
+func parseGraph6(data []byte, _ url.Values) (generator.Graph, error) {
+	line := strings.TrimPrefix(strings.TrimSpace(string(data)), ">>graph6<<")
+	if strings.ContainsAny(line, "\n\r") {
+		return nil, importError("only one graph can be imported")
+	}
+	for _, c := range []byte(line) {
+		if c < 63 || c > 126 {
+			return nil, importError("invalid graph6 character %q", c)
+		}
+	}
+
+	n, rest, err := graph6ParseSize([]byte(line))
+	if err != nil {
+		return nil, err
+	}
+	if n > maxGraph6Nodes {
+		return nil, importError("graph6 graph of %d nodes is too large", n)
+	}
+	if err = checkImportSize(n); err != nil {
+		return nil, err
+	}
+	bits := n * (n - 1) / 2
+	if len(rest) != (bits+5)/6 {
+		return nil, importError("graph6 data of %d nodes must have %d bytes", n, (bits+5)/6)
+	}
+	builder := newGraphBuilder()
+	builder.size = n
+	bit := 0
+	for j := 1; j < n; j++ {
+		for i := 0; i < j; i++ {
+			if (rest[bit/6]-63)>>(5-bit%6)&1 == 1 {
+				if err := builder.addEdge(strconv.Itoa(i), strconv.Itoa(j), ""); err != nil {
+					return nil, err
+				}
+			}
+			bit++
+		}
+	}
+	return builder.build()
+}
+
+// graph6ParseSize decodes number of nodes written by graph6Size and returns the remaining data.
+func graph6ParseSize(data []byte) (int, []byte, error) {
+	length := 1
+	switch {
+	case len(data) >= 2 && data[0] == 126 && data[1] == 126:
+		length = 8
+	case len(data) >= 1 && data[0] == 126:
+		length = 4
+	}
+	if len(data) < length {
+		return 0, nil, importError("graph6 data is too short")
+	}
+	if length == 1 {
+		return int(data[0]) - 63, data[1:], nil
+	}
+	// sizes of four and eight bytes start with one and two bytes 126
+	n := 0
+	for _, c := range data[length/4 : length] {
+		n = n<<6 | int(c-63)
+	}
+	return n, data[length:], nil
+}
+
+// parseJSON reads document written by BasicJSONGraph including weights of vertices
+// and attributes, attribute values have to be strings or numbers.
+func parseJSON(data []byte, _ url.Values) (generator.Graph, error) {
+	var document BasicJSONGraph
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, importError("%s", err.Error())
+	}
+	builder := newGraphBuilder()
+	builder.named = document.Properties.Named
+	builder.float = document.Properties.FloatWeights
+	builder.decimals = document.Properties.WeightPrecision
+	if builder.decimals < 0 || builder.decimals > MaxWeightPrecision {
+		return nil, importError("invalid weight precision %d", builder.decimals)
+	}
+
+	names := make(map[int]string, len(document.Nodes))
+	for k, node := range document.Nodes {
+		if node.ID != k {
+			return nil, importError("node %d has id %d, nodes must be ordered by ids", k, node.ID)
+		}
+		names[k] = strconv.Itoa(k)
+		if builder.named {
+			names[k] = node.Label
+		}
+		if _, ok := builder.index[names[k]]; ok {
+			return nil, importError("duplicate node label %q", names[k])
+		}
+		builder.addNode(names[k])
+		if node.Weight != nil {
+			if err := builder.setVertexWeight(names[k], strconv.FormatFloat(*node.Weight, 'f', -1, 64)); err != nil {
+				return nil, err
+			}
+		}
+		for name, v := range node.Attributes {
+			value, ok := jsonAttribute(v)
+			if !ok {
+				return nil, importError("attribute %q of node %d isn't string or number", name, k)
+			}
+			builder.setVertexAttribute(names[k], name, value)
+		}
+	}
+	builder.size = len(document.Nodes)
+
+	for _, edge := range document.Edges {
+		left, ok1 := names[edge.Source]
+		right, ok2 := names[edge.Target]
+		if !ok1 || !ok2 {
+			return nil, importError("edge %d-%d references unknown node", edge.Source, edge.Target)
+		}
+		if left == right {
+			return nil, importError("loop on node %d", edge.Source)
+		}
+		if err := builder.addEdge(left, right, ""); err != nil {
+			return nil, err
+		}
+		for name, v := range edge.Attributes {
+			value, ok := jsonAttribute(v)
+			if !ok {
+				return nil, importError("attribute %q of edge %d-%d isn't string or number", name, edge.Source, edge.Target)
+			}
+			builder.setEdgeAttribute(left, right, name, value)
+		}
+		if edge.Weight == nil {
+			continue
+		}
+		builder.weighted = true
+		if *edge.Weight != math.Trunc(*edge.Weight) {
+			builder.float = true
+		}
+		key := [2]string{left, right}
+		if builder.index[left] > builder.index[right] {
+			key = [2]string{right, left}
+		}
+		builder.edges[key] = *edge.Weight
+	}
+	builder.weighted = builder.weighted || document.Properties.Weighted
+	return builder.build()
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// assertSameGraph compares edges, weights and names of the graphs.
+func assertSameGraph(t *testing.T, expected, actual generator.Graph, format string) {
+	assert.Equal(t, len(expected.Edges()), len(actual.Edges()), format)
+	for k := range expected.Edges() {
+		for j := range expected.Edges()[k] {
+			assert.True(t, actual.Edges()[k][j], format)
+		}
+		assert.Len(t, actual.Edges()[k], len(expected.Edges()[k]), format)
+	}
+	assert.Equal(t, expected.Properties().Weighted(), actual.Properties().Weighted(), format)
+	assert.Equal(t, expected.Properties().Float(), actual.Properties().Float(), format)
+	expectedWeights, _ := generator.FloatWeights(expected)
+	actualWeights, _ := generator.FloatWeights(actual)
+	if expected.Properties().Weighted() {
+		assert.Equal(t, expectedWeights, actualWeights, format)
+	}
+	assert.Equal(t, namedLabels(expected), namedLabels(actual), format)
+}
+
+func TestImportRoundTrip(t *testing.T) {
+	weighted := generator.WeightedGraph{
+		ParentGraph: trianglePlusNode,
+		WeightsMap:  map[generator.WeightedEdge]int{{Left: 0, Right: 1}: 3, {Left: 0, Right: 2}: -1, {Left: 1, Right: 2}: 1},
+	}
+	named := generator.NamedGraph{ParentGraph: trianglePlusNode, VertexNames: []string{"a b", "c\"d", "e", "f"}}
+	float := generator.FloatWeightedGraph{
+		ParentGraph: trianglePlusNode,
+		WeightsMap:  map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: 1.25, {Left: 0, Right: 2}: 2, {Left: 1, Right: 2}: -0.5},
+		Decimals:    2,
+	}
+	formats := []struct {
+		name       string
+		translator GraphTranslator
+		options    url.Values
+		graphs     []generator.Graph
+	}{
+		{"matrix", &MatrixGraph{Names: true}, nil, []generator.Graph{trianglePlusNode, weighted, named, float, attributedTestGraph()}},
+		{"dot", &DotGraph{}, nil, []generator.Graph{trianglePlusNode, weighted, named, float, attributedTestGraph()}},
+		{"edgelist", &EdgeListGraph{Header: true}, url.Values{"header": {"true"}}, []generator.Graph{trianglePlusNode, weighted, float}},
+		{"JSON", &BasicJSONGraph{}, nil, []generator.Graph{trianglePlusNode, weighted, named, float, attributedTestGraph()}},
+		{"graph6", &Graph6Graph{}, nil, []generator.Graph{trianglePlusNode, graphFromEdges(70, [][2]int{{0, 69}, {5, 6}})}},
+	}
+	for _, f := range formats {
+		for _, g := range f.graphs {
+			assert.True(t, f.translator.Convert(g))
+			imported, err := ParseGraph(f.name, f.translator.Bytes(), f.options)
+			if assert.NoError(t, err, f.name) {
+				assertSameGraph(t, g, imported, f.name)
+			}
+		}
+	}
+}
+
+func TestMatrixNames(t *testing.T) {
+	named := generator.NamedGraph{ParentGraph: trianglePlusNode, VertexNames: []string{"a", "b", "c", "d"}}
+	translator, _ := TranslatorByName("matrix", nil)
+	assert.True(t, translator.Convert(named))
+	assert.Equal(t, "0 1 1 0\n", strings.SplitAfter(string(translator.Bytes()), "\n")[0])
+
+	translator, _ = TranslatorByName("matrix", url.Values{"names": {"true"}})
+	assert.True(t, translator.Convert(named))
+	assert.True(t, strings.HasPrefix(string(translator.Bytes()), `# "a" "b" "c" "d"`+"\n0 1 1 0\n"))
+}
+
+func TestImportAttributes(t *testing.T) {
+	expected := attributedTestGraph()
+	expectedWeights, _ := generator.VertexWeights(expected)
+	for _, translator := range []GraphTranslator{&DotGraph{}, &BasicJSONGraph{}} {
+		assert.True(t, translator.Convert(expected))
+		imported, err := ParseGraph(translator.Extension(), translator.Bytes(), nil)
+		if !assert.NoError(t, err, translator.Kind()) {
+			continue
+		}
+		weights, _ := generator.VertexWeights(imported)
+		assert.Equal(t, expectedWeights, weights, translator.Kind())
+		assert.Equal(t, generator.VertexAttributes(expected), generator.VertexAttributes(imported), translator.Kind())
+		assert.Equal(t, generator.EdgeAttributes(expected), generator.EdgeAttributes(imported), translator.Kind())
+		assert.True(t, ImportedRequest(imported).VertexWeighted)
+	}
+
+	for format, data := range map[string]string{
+		"dot":  `graph { a [weight=x] }`,
+		"JSON": `{"nodes": [{"id": 0, "attributes": {"weight": 2}}]}`,
+	} {
+		_, err := ParseGraph(format, []byte(data), nil)
+		assert.ErrorIs(t, err, ErrInvalidGraphData, format)
+	}
+	_, err := ParseGraph("dot", []byte(`graph { a -- b ["bad name"=1] }`), nil)
+	assert.ErrorIs(t, err, ErrInvalidGraphData)
+	_, err = ParseGraph("JSON", []byte(`{"nodes": [{"id": 0, "attributes": {"tags": ["a"]}}]}`), nil)
+	assert.ErrorIs(t, err, ErrInvalidGraphData)
+}
+
+func TestParseDot(t *testing.T) {
+	graph, err := ParseGraph("dot", []byte(`/* hand-made */
+graph G {
+	node [shape=circle];
+	rankdir = LR
+	x -- y -- "z w" [weight=2.5, color="red"]
+	x--"z w"; // comment
+	lonely
+}`), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"x", "y", "z w", "lonely"}, graph.Nodes())
+	weights, decimals := generator.FloatWeights(graph)
+	assert.Equal(t, 1, decimals)
+	assert.Equal(t, map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: 2.5, {Left: 1, Right: 2}: 2.5, {Left: 0, Right: 2}: 1}, weights)
+
+	for _, invalid := range []string{
+		"digraph { a -> b }",
+		"graph { a -> b }",
+		"graph { subgraph s { a } }",
+		"graph { a -- a }",
+		"graph { a:n -- b }",
+		`graph { "a -- b }`,
+		"graph { a -- b",
+		"graph { a } graph { b }",
+	} {
+		_, err := ParseGraph("dot", []byte(invalid), nil)
+		assert.ErrorIs(t, err, ErrInvalidGraphData, invalid)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		format, data string
+	}{
+		{"matrix", "0 1\n0 0\n"},
+		{"matrix", "0 1 0\n1 0\n"},
+		{"matrix", "1 0\n0 0\n"},
+		{"matrix", "0 x\nx 0\n"},
+		{"matrix", ""},
+		{"edgelist", "0 1 2 3\n"},
+		{"edgelist", "0 1 heavy\n"},
+		{"graph6", "C~\nC~\n"},
+		{"graph6", "C~~"},
+		{"graph6", "~~~~~~~~"},
+		{"JSON", `{"nodes": [{"id": 1}]}`},
+		{"JSON", `{"nodes": [{"id": 0}], "edges": [{"source": 0, "target": 3}]}`},
+	}
+	for _, c := range cases {
+		_, err := ParseGraph(c.format, []byte(c.data), nil)
+		assert.ErrorIs(t, err, ErrInvalidGraphData, c)
+	}
+
+	_, err := ParseGraph("edgelist", []byte("3 2\n0 1\n"), url.Values{"header": {"true"}})
+	assert.ErrorIs(t, err, ErrInvalidGraphData)
+	_, err = ParseGraph("svg", []byte("<svg/>"), nil)
+	assert.ErrorIs(t, err, ErrImportNotSupported)
+}
+
+func TestImportSizeLimit(t *testing.T) {
+	max := configuration.Default().MaxNodes
+	cases := []struct {
+		format, data string
+		options      url.Values
+	}{
+		{"edgelist", "0 1000000000\n", nil},
+		{"edgelist", fmt.Sprintf("0 %d\n", max), nil},
+		{"edgelist", "1000000000 0\n", url.Values{"header": {"true"}}},
+		{"dot", "graph { 0 -- 1000000000 }", nil},
+		{"graph6", "~?~~", nil},
+	}
+	for _, c := range cases {
+		_, err := ParseGraph(c.format, []byte(c.data), c.options)
+		assert.ErrorIs(t, err, ErrInvalidGraphData, c)
+		assert.ErrorContains(t, err, "permitted", c)
+	}
+
+	graph, err := ParseGraph("edgelist", []byte(fmt.Sprintf("0 %d\n", max-1)), nil)
+	assert.NoError(t, err)
+	assert.Len(t, graph.Edges(), max)
+}
+
+func TestImportedRequest(t *testing.T) {
+	graph, err := ParseGraph("edges", []byte("a b 1.50\nb c\n"), nil)
+	assert.NoError(t, err)
+	request := ImportedRequest(graph)
+	assert.Equal(t, GraphRequest{Type: Imported, Nodes: 3, Weighted: true, FloatWeights: true, WeightPrecision: 2}, request)
+	assert.True(t, request.ValidImport())
+	assert.False(t, request.Valid())
+	assert.Equal(t, []string{"JSON", "dot", "edgelist", "graph6", "matrix"}, ImportFormats())
+}
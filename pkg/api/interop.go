@@ -0,0 +1,115 @@
+package api
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"strconv"
+)
+
+// elementData merges attributes with the reserved keys of element, the reserved keys take precedence.
+func elementData(attributes generator.Attributes, reserved map[string]any) map[string]any {
+	result := attributeValues(attributes)
+	for k, v := range reserved {
+		result[k] = v
+	}
+	return result
+}
+
+// nodeData returns attributes of node with its name and weight, if the graph has them.
+func (d *exportData) nodeData(k int, reserved map[string]any) map[string]any {
+	var attributes generator.Attributes
+	if k < len(d.vertexAttrs) {
+		attributes = d.vertexAttrs[k]
+	}
+	if d.named {
+		reserved["label"] = d.labels[k]
+	}
+	return elementData(attributes, reserved)
+}
+
+// edgeData returns attributes of edge with its weight, if the graph is weighted.
+func (d *exportData) edgeData(e generator.WeightedEdge, reserved map[string]any) map[string]any {
+	if d.weighted {
+		reserved["weight"] = d.weights[e]
+	}
+	return elementData(d.edgeAttrs[e], reserved)
+}
+
+func (n *NetworkXGraph) Extension() string {
+	return "json"
+}
+
+func (n *NetworkXGraph) Kind() string {
+	return "networkx"
+}
+
+func (n *NetworkXGraph) ContentType() string {
+	return "application/json"
+}
+
+// Convert builds node-link document read by networkx.node_link_graph, nodes are identified
+// by their indexes and attributes are stored directly in nodes and links.
+func (n *NetworkXGraph) Convert(g generator.Graph) bool {
+	d := newExportData(g)
+	n.Directed, n.Multigraph = false, false
+	n.Graph = map[string]any{}
+	n.Nodes = make([]map[string]any, len(d.labels))
+	for k := range d.labels {
+		n.Nodes[k] = d.nodeData(k, map[string]any{"id": k})
+	}
+	n.Links = make([]map[string]any, len(d.edges))
+	for k, e := range d.edges {
+		n.Links[k] = d.edgeData(e, map[string]any{"source": e.Left, "target": e.Right})
+	}
+	return true
+}
+
+func (n *NetworkXGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	return writer, json.NewEncoder(writer).Encode(n)
+}
+
+func (n *NetworkXGraph) Bytes() []byte {
+	b, _ := json.Marshal(n)
+	return b
+}
+
+func (c *CytoscapeGraph) Extension() string {
+	return "json"
+}
+
+func (c *CytoscapeGraph) Kind() string {
+	return "cytoscape"
+}
+
+func (c *CytoscapeGraph) ContentType() string {
+	return "application/json"
+}
+
+// Convert builds elements document of Cytoscape.js, ids are strings n0, n1, ...
+// for nodes and e0, e1, ... for edges and attributes are stored in data of elements.
+func (c *CytoscapeGraph) Convert(g generator.Graph) bool {
+	d := newExportData(g)
+	c.Elements.Nodes = make([]CytoscapeElement, len(d.labels))
+	for k := range d.labels {
+		c.Elements.Nodes[k].Data = d.nodeData(k, map[string]any{"id": "n" + strconv.Itoa(k)})
+	}
+	c.Elements.Edges = make([]CytoscapeElement, len(d.edges))
+	for k, e := range d.edges {
+		c.Elements.Edges[k].Data = d.edgeData(e, map[string]any{
+			"id":     "e" + strconv.Itoa(k),
+			"source": "n" + strconv.Itoa(e.Left),
+			"target": "n" + strconv.Itoa(e.Right),
+		})
+	}
+	return true
+}
+
+func (c *CytoscapeGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	return writer, json.NewEncoder(writer).Encode(c)
+}
+
+func (c *CytoscapeGraph) Bytes() []byte {
+	b, _ := json.Marshal(c)
+	return b
+}
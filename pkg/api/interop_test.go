@@ -0,0 +1,37 @@
+package api
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNetworkX(t *testing.T) {
+	translator := &NetworkXGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	var decoded map[string]any
+	assert.Nil(t, json.Unmarshal(translator.Bytes(), &decoded))
+	assert.Equal(t, false, decoded["directed"])
+	assert.Equal(t, false, decoded["multigraph"])
+	nodes := decoded["nodes"].([]any)
+	assert.Len(t, nodes, 3)
+	assert.Equal(t, map[string]any{"id": 0.0, "label": "a", "color": "red & blue", "weight": 1.0}, nodes[0])
+	links := decoded["links"].([]any)
+	assert.Equal(t, map[string]any{"source": 1.0, "target": 2.0, "weight": -2.0, "capacity": 4.0}, links[1])
+}
+
+func TestCytoscape(t *testing.T) {
+	translator := &CytoscapeGraph{}
+	assert.True(t, translator.Convert(trianglePlusNode))
+	var decoded CytoscapeGraph
+	assert.Nil(t, json.Unmarshal(translator.Bytes(), &decoded))
+	assert.Len(t, decoded.Elements.Nodes, 4)
+	assert.Equal(t, map[string]any{"id": "n3"}, decoded.Elements.Nodes[3].Data)
+	assert.Equal(t, map[string]any{"id": "e2", "source": "n1", "target": "n2"}, decoded.Elements.Edges[2].Data)
+
+	translator.Convert(attributedTestGraph())
+	decoded = CytoscapeGraph{}
+	assert.Nil(t, json.Unmarshal(translator.Bytes(), &decoded))
+	assert.Equal(t, 1.5, decoded.Elements.Edges[0].Data["weight"])
+	assert.Equal(t, "b", decoded.Elements.Nodes[1].Data["label"])
+}
@@ -1,10 +1,47 @@
 package api
 
 import (
+	"github.com/goccy/go-json"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"io"
+	"sort"
 )
 
+// JSONSchemaVersion is the version of JSON graph document, it is increased
+// on every incompatible change of the document.
+const JSONSchemaVersion = 1
+
+// RequestTranslator is translator able to include the request of graph in its output.
+type RequestTranslator interface {
+	GraphTranslator
+	SetRequest(request *GraphRequest)
+}
+
+type JSONNode struct {
+	ID         int            `json:"id"`
+	Label      string         `json:"label"`
+	Weight     *float64       `json:"weight,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type JSONEdge struct {
+	Source     int            `json:"source"`
+	Target     int            `json:"target"`
+	Weight     *float64       `json:"weight,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type JSONGraphProperties struct {
+	Directed        bool `json:"directed"`
+	Named           bool `json:"named"`
+	Weighted        bool `json:"weighted"`
+	FloatWeights    bool `json:"float_weights"`
+	VertexWeighted  bool `json:"vertex_weighted"`
+	WeightPrecision int  `json:"weight_precision"`
+	Nodes           int  `json:"nodes"`
+	Edges           int  `json:"edges"`
+}
+
 func (j *BasicJSONGraph) Extension() string {
 	return "json"
 }
@@ -13,20 +50,71 @@ func (j *BasicJSONGraph) Kind() string {
 	return "JSON"
 }
 
+// SetRequest stores parameters of request the graph was generated from, nil clears them.
+// The request is kept across conversions of multiple graphs until set again.
+func (j *BasicJSONGraph) SetRequest(request *GraphRequest) {
+	j.Request, j.Seed = request, nil
+	if request != nil {
+		j.Seed = request.Seed
+	}
+}
+
 func (j *BasicJSONGraph) Convert(g generator.Graph) bool {
-	j.Nodes = make([]string, len(g.Nodes()))
-	copy(j.Nodes, g.Nodes())
+	labels := nodeLabels(g)
+	weights, precision := generator.FloatWeights(g)
+	vertexWeights, vertexPrecision := generator.VertexWeights(g)
+	vertexAttrs := generator.VertexAttributes(g)
+	edgeAttrs := generator.EdgeAttributes(g)
+	properties := g.Properties()
+
+	j.Version = JSONSchemaVersion
+	j.Nodes = make([]JSONNode, len(labels))
+	for k := range j.Nodes {
+		j.Nodes[k] = JSONNode{ID: k, Label: labels[k]}
+		if vertexWeights != nil {
+			weight := vertexWeights[k]
+			j.Nodes[k].Weight = &weight
+		}
+		if k < len(vertexAttrs) && len(vertexAttrs[k]) != 0 {
+			j.Nodes[k].Attributes = attributeValues(vertexAttrs[k])
+		}
+	}
 
-	j.Edges = make(map[string][]string)
+	j.Edges = make([]JSONEdge, 0)
 	for from, to := range g.Edges() {
-		fromName := g.Nodes()[from]
-		namedEdges := make([]string, len(to))
-		for i := range to {
-			namedEdges[i] = g.Nodes()[i]
+		neighbours := make([]int, 0, len(to))
+		for i, ok := range to {
+			if ok && i > from {
+				neighbours = append(neighbours, i)
+			}
+		}
+		sort.Ints(neighbours)
+		for _, i := range neighbours {
+			edge := generator.CreateEdge(from, i)
+			jsonEdge := JSONEdge{Source: from, Target: i}
+			if properties.Weighted() {
+				weight := weights[edge]
+				jsonEdge.Weight = &weight
+			}
+			if len(edgeAttrs[edge]) != 0 {
+				jsonEdge.Attributes = attributeValues(edgeAttrs[edge])
+			}
+			j.Edges = append(j.Edges, jsonEdge)
 		}
-		j.Edges[fromName] = namedEdges
 	}
 
+	if !properties.Weighted() {
+		precision = vertexPrecision
+	}
+	j.Properties = JSONGraphProperties{
+		Named:           properties.Named(),
+		Weighted:        properties.Weighted(),
+		FloatWeights:    properties.Float(),
+		VertexWeighted:  properties.VertexWeighted(),
+		WeightPrecision: precision,
+		Nodes:           len(j.Nodes),
+		Edges:           len(j.Edges),
+	}
 	return true
 }
 
@@ -34,28 +122,11 @@ func (j *BasicJSONGraph) ContentType() string {
 	return "application/json"
 }
 
-//func (j *BasicJSONGraph) Serialize() []byte {
-//	b, _ := json.Marshal(j)
-//	return b
-//}
-
-func (j *WeightedJSONGraph) ContentType() string {
-	return "application/json"
-}
-
-func (j *WeightedJSONGraph) Convert(g generator.Graph) bool {
-	j.Nodes = make([]string, len(g.Nodes()))
-	copy(j.Nodes, g.Nodes())
-
-	return false
-}
-
 func (j *BasicJSONGraph) Serialize(writer io.Writer) (io.Writer, error) {
-	//TODO implement me
-	panic("implement me")
+	return writer, json.NewEncoder(writer).Encode(j)
 }
 
 func (j *BasicJSONGraph) Bytes() []byte {
-	//TODO implement me
-	panic("implement me")
+	b, _ := json.Marshal(j)
+	return b
 }
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJSONGraph(t *testing.T) {
+	path := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	weighted := generator.FloatWeightedGraph{
+		ParentGraph: path,
+		WeightsMap:  map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: 1.5, {Left: 1, Right: 2}: -2},
+		Decimals:    1,
+	}
+	attributed := generator.AttributedGraph{
+		ParentGraph:    weighted,
+		EdgeAttributes: map[generator.WeightedEdge]generator.Attributes{{Left: 1, Right: 2}: {"color": generator.StringAttr("red")}},
+	}
+	named := generator.NamedGraph{ParentGraph: attributed, VertexNames: []string{"a", "b", "c"}}
+	seed := int64(7)
+
+	translator := &BasicJSONGraph{}
+	translator.SetRequest(&GraphRequest{Type: Complete, Nodes: 3, Seed: &seed})
+	assert.True(t, translator.Convert(named))
+	buffer := bytes.Buffer{}
+	_, err := translator.Serialize(&buffer)
+	assert.Nil(t, err)
+
+	var decoded BasicJSONGraph
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Equal(t, JSONSchemaVersion, decoded.Version)
+	assert.Equal(t, seed, *decoded.Seed)
+	assert.Equal(t, Complete, decoded.Request.Type)
+	assert.True(t, decoded.Properties.Named && decoded.Properties.Weighted && decoded.Properties.FloatWeights)
+	assert.Equal(t, 1, decoded.Properties.WeightPrecision)
+	assert.Equal(t, []string{"a", "b", "c"}, []string{decoded.Nodes[0].Label, decoded.Nodes[1].Label, decoded.Nodes[2].Label})
+	assert.Len(t, decoded.Edges, 2)
+	assert.Equal(t, 1.5, *decoded.Edges[0].Weight)
+	assert.Equal(t, "red", decoded.Edges[1].Attributes["color"])
+
+	translator.SetRequest(nil)
+	assert.True(t, translator.Convert(path))
+	decoded = BasicJSONGraph{}
+	assert.Nil(t, json.Unmarshal(translator.Bytes(), &decoded))
+	assert.Nil(t, decoded.Request)
+	assert.Equal(t, "2", decoded.Nodes[2].Label)
+	assert.Nil(t, decoded.Edges[0].Weight)
+}
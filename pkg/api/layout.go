@@ -0,0 +1,166 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math"
+	"math/rand"
+)
+
+// PositionXAttribute and PositionYAttribute are names of vertex attributes
+// holding coordinates of vertices used by layouts instead of computed ones.
+const (
+	PositionXAttribute = "x"
+	PositionYAttribute = "y"
+)
+
+// layoutSeed makes the computed layouts reproducible.
+const layoutSeed = 1
+
+type point struct {
+	X, Y float64
+}
+
+// givenLayout returns positions stored in vertex attributes, false if any vertex misses them.
+func givenLayout(d *exportData) ([]point, bool) {
+	result := make([]point, len(d.labels))
+	for k := range result {
+		x, okX := d.vertexAttr(k, PositionXAttribute)
+		y, okY := d.vertexAttr(k, PositionYAttribute)
+		if !okX || !okY || x.Kind == generator.StringKind || y.Kind == generator.StringKind {
+			return nil, false
+		}
+		result[k] = point{X: attributeNumber(x), Y: attributeNumber(y)}
+	}
+	return result, true
+}
+
+// computeLayout chooses the layout of graph, positions carried by the graph are used when
+// every vertex has them, regular graphs are drawn on circle and all other graphs by
+// Fruchterman-Reingold algorithm. The layout is deterministic.
+func computeLayout(d *exportData) []point {
+	if positions, ok := givenLayout(d); ok {
+		return positions
+	}
+	neighbours := make([][]int, len(d.labels))
+	for _, e := range d.edges {
+		neighbours[e.Left] = append(neighbours[e.Left], e.Right)
+		neighbours[e.Right] = append(neighbours[e.Right], e.Left)
+	}
+	if regular(neighbours) {
+		return circularLayout(len(neighbours))
+	}
+	return forceDirectedLayout(neighbours, layoutIterations(len(neighbours)))
+}
+
+// layoutIterations lowers number of iterations of the quadratic layout for large graphs.
+func layoutIterations(nodes int) int {
+	if nodes > 300 {
+		return 50
+	}
+	return 200
+}
+
+// circularLayout places the nodes evenly on unit circle.
+func circularLayout(nodes int) []point {
+	result := make([]point, nodes)
+	for k := range result {
+		angle := 2 * math.Pi * float64(k) / float64(nodes)
+		result[k] = point{X: math.Cos(angle), Y: math.Sin(angle)}
+	}
+	return result
+}
+
+// regular reports whether all the nodes have the same degree.
+func regular(neighbours [][]int) bool {
+	for k := range neighbours {
+		if len(neighbours[k]) != len(neighbours[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// forceDirectedLayout computes Fruchterman-Reingold layout in unit square, connected nodes
+// attract and all the nodes repel each other, moves are limited by cooling temperature.
+func forceDirectedLayout(neighbours [][]int, iterations int) []point {
+	n := len(neighbours)
+	rng := rand.New(rand.NewSource(layoutSeed))
+	positions := make([]point, n)
+	for k := range positions {
+		positions[k] = point{X: rng.Float64(), Y: rng.Float64()}
+	}
+	if n < 2 {
+		return positions
+	}
+	k := math.Sqrt(1 / float64(n))
+	displacement := make([]point, n)
+	for it := 0; it < iterations; it++ {
+		temperature := 0.1 * (1 - float64(it)/float64(iterations))
+		for v := range displacement {
+			displacement[v] = point{}
+		}
+		for v := 0; v < n; v++ {
+			for u := v + 1; u < n; u++ {
+				dx, dy := positions[v].X-positions[u].X, positions[v].Y-positions[u].Y
+				dist := math.Max(math.Hypot(dx, dy), 1e-6)
+				force := k * k / dist
+				displacement[v].X += dx / dist * force
+				displacement[v].Y += dy / dist * force
+				displacement[u].X -= dx / dist * force
+				displacement[u].Y -= dy / dist * force
+			}
+		}
+		for v := range neighbours {
+			for _, u := range neighbours[v] {
+				if u < v {
+					continue
+				}
+				dx, dy := positions[v].X-positions[u].X, positions[v].Y-positions[u].Y
+				dist := math.Max(math.Hypot(dx, dy), 1e-6)
+				force := dist * dist / k
+				displacement[v].X -= dx / dist * force
+				displacement[v].Y -= dy / dist * force
+				displacement[u].X += dx / dist * force
+				displacement[u].Y += dy / dist * force
+			}
+		}
+		for v := range positions {
+			length := math.Max(math.Hypot(displacement[v].X, displacement[v].Y), 1e-9)
+			step := math.Min(length, temperature)
+			positions[v].X += displacement[v].X / length * step
+			positions[v].Y += displacement[v].Y / length * step
+		}
+	}
+	return positions
+}
+
+// fitLayout scales and centers the positions in box of passed size, keeping the margin on every side.
+func fitLayout(positions []point, width, height, margin float64) []point {
+	if len(positions) == 0 {
+		return positions
+	}
+	minX, minY := positions[0].X, positions[0].Y
+	maxX, maxY := minX, minY
+	for _, p := range positions {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	scale := math.Min((width-2*margin)/math.Max(maxX-minX, 1e-9), (height-2*margin)/math.Max(maxY-minY, 1e-9))
+	offsetX := (width - (maxX-minX)*scale) / 2
+	offsetY := (height - (maxY-minY)*scale) / 2
+	result := make([]point, len(positions))
+	for k, p := range positions {
+		result[k] = point{X: offsetX + (p.X-minX)*scale, Y: offsetY + (p.Y-minY)*scale}
+	}
+	return result
+}
+
+func attributeNumber(a generator.Attribute) float64 {
+	switch a.Kind {
+	case generator.IntKind:
+		return float64(a.Int)
+	case generator.FloatKind:
+		return a.Float
+	}
+	return 0
+}
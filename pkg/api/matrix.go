@@ -2,9 +2,12 @@ package api
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 func (m *MatrixGraph) Extension() string {
@@ -15,9 +18,14 @@ func (m *MatrixGraph) Kind() string {
 	return "matrix"
 }
 func (m *MatrixGraph) Convert(g generator.Graph) bool {
-	m.edges = make([][]int, len(g.Edges()))
+	weights, precision := generator.FloatWeights(g)
+	m.precision = precision
+	m.labels = namedLabels(g)
+	m.vertexAttrs = vertexAttributes(g)
+	m.edgeAttrs = generator.EdgeAttributes(g)
+	m.edges = make([][]float64, len(g.Edges()))
 	for k := range m.edges {
-		m.edges[k] = make([]int, len(g.Edges()))
+		m.edges[k] = make([]float64, len(g.Edges()))
 		for j, v := range g.Edges()[k] {
 			if !v {
 				continue
@@ -31,7 +39,7 @@ func (m *MatrixGraph) Convert(g generator.Graph) bool {
 					Left:  l,
 					Right: r,
 				}
-				m.edges[k][j] = g.Weights()[edge]
+				m.edges[k][j] = weights[edge]
 			} else {
 				m.edges[k][j] = 1
 			}
@@ -40,7 +48,19 @@ func (m *MatrixGraph) Convert(g generator.Graph) bool {
 	return true
 }
 
+// Serialize writes rows of the matrix, with Names the rows of named graphs are preceded
+// by comment line with quoted names of the nodes in order of rows.
 func (m *MatrixGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	if m.Names && m.labels != nil {
+		quoted := make([]string, len(m.labels))
+		for k, v := range m.labels {
+			quoted[k] = strconv.Quote(v)
+		}
+		_, err := writer.Write([]byte("# " + strings.Join(quoted, " ") + "\n"))
+		if err != nil {
+			return writer, err
+		}
+	}
 	for k := range m.edges {
 		for j, v := range m.edges[k] {
 			if j != 0 {
@@ -49,7 +69,7 @@ func (m *MatrixGraph) Serialize(writer io.Writer) (io.Writer, error) {
 					return writer, err
 				}
 			}
-			_, err := writer.Write([]byte(strconv.Itoa(v)))
+			_, err := writer.Write([]byte(strconv.FormatFloat(v, 'f', m.precision, 64)))
 			if err != nil {
 				return writer, err
 			}
@@ -59,7 +79,44 @@ func (m *MatrixGraph) Serialize(writer io.Writer) (io.Writer, error) {
 			return writer, err
 		}
 	}
-	return writer, nil
+	return m.serializeAttributes(writer)
+}
+
+// serializeAttributes appends section with attributes of vertices and edges
+// after the matrix, one line per vertex or edge, when the graph has any.
+func (m *MatrixGraph) serializeAttributes(writer io.Writer) (io.Writer, error) {
+	if m.vertexAttrs == nil && len(m.edgeAttrs) == 0 {
+		return writer, nil
+	}
+	builder := strings.Builder{}
+	builder.WriteString("\n# vertex attributes\n")
+	for k, v := range m.vertexAttrs {
+		builder.WriteString(fmt.Sprintf("%s %s\n", m.node(k), formatAttributes(v, " ", quotedAttribute)))
+	}
+	builder.WriteString("# edge attributes\n")
+	edges := make([]generator.WeightedEdge, 0, len(m.edgeAttrs))
+	for k := range m.edgeAttrs {
+		edges = append(edges, k)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Left != edges[j].Left {
+			return edges[i].Left < edges[j].Left
+		}
+		return edges[i].Right < edges[j].Right
+	})
+	for _, e := range edges {
+		builder.WriteString(fmt.Sprintf("%s %s %s\n", m.node(e.Left), m.node(e.Right), formatAttributes(m.edgeAttrs[e], " ", quotedAttribute)))
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+// node returns quoted name of the node for named graphs, its index otherwise.
+func (m *MatrixGraph) node(k int) string {
+	if m.labels != nil {
+		return strconv.Quote(m.labels[k])
+	}
+	return strconv.Itoa(k)
 }
 
 func (m *MatrixGraph) ContentType() string {
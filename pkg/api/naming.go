@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxNameLength is the largest number of characters of name supplied by user.
+const MaxNameLength = 64
+
+type NamingScheme uint8
+
+const (
+	NumericNaming NamingScheme = iota
+	OneBasedNaming
+	AlphabeticNaming
+	CityNaming
+	ListNaming
+)
+
+var namingToString = map[NamingScheme]string{
+	NumericNaming:    "numeric",
+	OneBasedNaming:   "numeric-one-based",
+	AlphabeticNaming: "alphabetic",
+	CityNaming:       "cities",
+	ListNaming:       "list"}
+
+var stringToNaming = map[string]NamingScheme{
+	"numeric":           NumericNaming,
+	"numeric-one-based": OneBasedNaming,
+	"alphabetic":        AlphabeticNaming,
+	"cities":            CityNaming,
+	"list":              ListNaming}
+
+func (n NamingScheme) String() string {
+	return namingToString[n]
+}
+
+func (n NamingScheme) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('"')
+	buffer.WriteString(n.String())
+	buffer.WriteByte('"')
+	return buffer.Bytes(), nil
+}
+
+func (n *NamingScheme) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	val, ok := stringToNaming[str]
+	if !ok {
+		return ErrInvalidNaming
+	}
+	*n = val
+	return nil
+}
+
+// VertexNaming selects how the vertices of generated graph are named,
+// Names are used by the list scheme only and must be distinct.
+type VertexNaming struct {
+	Scheme NamingScheme `json:"scheme"`
+	Names  []string     `json:"names,omitempty"`
+}
+
+// validNaming checks the user supplied names, their number is checked against the number of nodes
+// only for requests without operations, the final size is known only after the generation otherwise.
+// There may be at most as many names as the permitted number of nodes, names are limited
+// to MaxNameLength characters and can't contain control characters.
+func (g *GraphRequest) validNaming() bool {
+	if g.Naming == nil || g.Naming.Scheme != ListNaming {
+		return true
+	}
+	if len(g.Naming.Names) > configuration.Default().MaxNodes {
+		return false
+	}
+	used := make(map[string]bool, len(g.Naming.Names))
+	for _, v := range g.Naming.Names {
+		if !validName(v) || used[v] {
+			return false
+		}
+		used[v] = true
+	}
+	return len(g.Operations) != 0 || len(g.Naming.Names) >= g.Nodes
+}
+
+func validName(name string) bool {
+	if name == "" || !utf8.ValidString(name) || utf8.RuneCountInString(name) > MaxNameLength {
+		return false
+	}
+	for _, c := range name {
+		if unicode.IsControl(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeLabels returns names of the nodes of graph, unnamed graphs are labeled by indexes of nodes.
+func nodeLabels(g generator.Graph) []string {
+	if g.Properties().Named() && len(g.Nodes()) == len(g.Edges()) {
+		return g.Nodes()
+	}
+	result := make([]string, len(g.Edges()))
+	for k := range result {
+		result[k] = strconv.Itoa(k)
+	}
+	return result
+}
+
+// namedLabels returns names of the nodes of named graph, nil for unnamed graphs.
+func namedLabels(g generator.Graph) []string {
+	if !g.Properties().Named() {
+		return nil
+	}
+	return nodeLabels(g)
+}
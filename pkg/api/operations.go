@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bytes"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+)
+
+type OperationType uint8
+
+const (
+	ComplementOp OperationType = iota
+	LineGraphOp
+	CartesianProductOp
+	TensorProductOp
+	StrongProductOp
+	DisjointUnionOp
+	JoinOp
+	SubdivisionOp
+	PermutationOp
+)
+
+var operationToString = map[OperationType]string{
+	ComplementOp:       "complement",
+	LineGraphOp:        "line-graph",
+	CartesianProductOp: "cartesian-product",
+	TensorProductOp:    "tensor-product",
+	StrongProductOp:    "strong-product",
+	DisjointUnionOp:    "disjoint-union",
+	JoinOp:             "join",
+	SubdivisionOp:      "subdivision",
+	PermutationOp:      "permutation"}
+
+var stringToOperation = map[string]OperationType{
+	"complement":        ComplementOp,
+	"line-graph":        LineGraphOp,
+	"cartesian-product": CartesianProductOp,
+	"tensor-product":    TensorProductOp,
+	"strong-product":    StrongProductOp,
+	"disjoint-union":    DisjointUnionOp,
+	"join":              JoinOp,
+	"subdivision":       SubdivisionOp,
+	"permutation":       PermutationOp}
+
+func (o OperationType) String() string {
+	return operationToString[o]
+}
+
+func (o OperationType) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('"')
+	buffer.WriteString(o.String())
+	buffer.WriteByte('"')
+	return buffer.Bytes(), nil
+}
+
+func (o *OperationType) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+
+	val, ok := stringToOperation[str]
+	if !ok {
+		return ErrInvalidOperation
+	}
+	*o = val
+	return nil
+}
+
+// Binary reports whether the operation needs second generated graph as an operand.
+func (o OperationType) Binary() bool {
+	switch o {
+	case CartesianProductOp, TensorProductOp, StrongProductOp, DisjointUnionOp, JoinOp:
+		return true
+	}
+	return false
+}
+
+// GraphOperation is one step of post-processing pipeline applied after generation.
+// Operand is generated from its own request and is required by binary operations only.
+type GraphOperation struct {
+	Operation OperationType `json:"operation"`
+	Operand   *GraphRequest `json:"operand,omitempty"`
+}
+
+// minEdges returns lower bound on number of edges of graph generated for the request,
+// operations are not taken into account.
+func (g *GraphRequest) minEdges() int {
+	edges := 0
+	switch g.Type {
+	case ExactDeg:
+		edges = g.Nodes * g.NodeDegree / 2
+	case BetweenDeg, AtLeastDeg:
+		edges = (g.Nodes*g.NodeDegree + 1) / 2
+	case AverageDeg:
+		edges = int((float32(g.Nodes) * g.NodeDegreeAverage) / 2.0)
+	case Complete:
+		edges = g.Nodes * (g.Nodes - 1) / 2
+	}
+	if g.Connected && edges < g.Nodes-1 {
+		edges = g.Nodes - 1
+	}
+	return edges
+}
+
+// validOperations checks operands of all operations and tracks the lower bound of number of nodes
+// through the pipeline, the request is rejected when it surely exceeds the configured maximum.
+func (g *GraphRequest) validOperations() bool {
+	nodes, edges := g.Nodes, g.minEdges()
+	for _, op := range g.Operations {
+		if op.Operation.Binary() != (op.Operand != nil) {
+			return false
+		}
+		otherNodes, otherEdges := 0, 0
+		if op.Operand != nil {
+			if !op.Operand.Valid() {
+				return false
+			}
+			otherNodes, otherEdges = op.Operand.Nodes, op.Operand.minEdges()
+		}
+		switch op.Operation {
+		case ComplementOp:
+			edges = 0
+		case LineGraphOp:
+			nodes, edges = edges, 0
+		case SubdivisionOp:
+			nodes, edges = nodes+edges, 2*edges
+		case CartesianProductOp:
+			nodes, edges = nodes*otherNodes, nodes*otherEdges+otherNodes*edges
+		case TensorProductOp:
+			nodes, edges = nodes*otherNodes, 2*edges*otherEdges
+		case StrongProductOp:
+			nodes, edges = nodes*otherNodes, nodes*otherEdges+otherNodes*edges+2*edges*otherEdges
+		case DisjointUnionOp:
+			nodes, edges = nodes+otherNodes, edges+otherEdges
+		case JoinOp:
+			nodes, edges = nodes+otherNodes, edges+otherEdges+nodes*otherNodes
+		}
+		if nodes > configuration.Default().MaxNodes {
+			return false
+		}
+	}
+	return true
+}
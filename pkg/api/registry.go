@@ -0,0 +1,302 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrFormatRegistered  = errors.New("format with this name is already registered")
+	ErrUnsupportedFormat = errors.New("requested format is not supported")
+	ErrInvalidOptions    = errors.New("invalid options of format")
+	ErrUnrepresentable   = errors.New("graph can't be represented in requested format")
+)
+
+// DefaultFormat is the format used when the client doesn't ask for a particular one.
+const DefaultFormat = "matrix"
+
+// TranslatorFactory creates new translator of format, options are the query parameters
+// of the download and may be nil.
+type TranslatorFactory func(options url.Values) GraphTranslator
+
+// FormatInfo describes registered format, it is listed by the formats endpoint.
+type FormatInfo struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Extension    string   `json:"extension"`
+	ContentType  string   `json:"content_type"`
+	MediaTypes   []string `json:"media_types"`
+	Options      []string `json:"options,omitempty"`
+	Concatenable bool     `json:"concatenable"`
+	Importable   bool     `json:"importable"`
+}
+
+type registeredFormat struct {
+	info    FormatInfo
+	factory TranslatorFactory
+}
+
+type formatRegistry struct {
+	lock       sync.RWMutex
+	formats    []registeredFormat
+	names      map[string]int
+	extensions map[string]int
+	mediaTypes map[string]int
+}
+
+var registry = formatRegistry{
+	names:      make(map[string]int),
+	extensions: make(map[string]int),
+	mediaTypes: make(map[string]int),
+}
+
+// RegisterFormat adds format to the registry. Extension and content type are taken
+// from the translator made with no options, the content type is accepted in addition
+// to mediaTypes. Extensions and media types shared by more formats select the format
+// registered first.
+func RegisterFormat(name, description string, mediaTypes, options []string, factory TranslatorFactory) error {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	if _, ok := registry.names[name]; ok {
+		return ErrFormatRegistered
+	}
+	translator := factory(nil)
+	info := FormatInfo{
+		Name:        name,
+		Description: description,
+		Extension:   translator.Extension(),
+		ContentType: translator.ContentType(),
+		MediaTypes:  append([]string{translator.ContentType()}, mediaTypes...),
+		Options:     options,
+		Importable:  graphParsers[name] != nil,
+	}
+	if ct, ok := translator.(ConcatTranslator); ok {
+		info.Concatenable = ct.Concatenable()
+	}
+
+	index := len(registry.formats)
+	registry.formats = append(registry.formats, registeredFormat{info: info, factory: factory})
+	registry.names[name] = index
+	if _, ok := registry.extensions[info.Extension]; !ok {
+		registry.extensions[info.Extension] = index
+	}
+	for _, mediaType := range info.MediaTypes {
+		if _, ok := registry.mediaTypes[mediaType]; !ok {
+			registry.mediaTypes[mediaType] = index
+		}
+	}
+	return nil
+}
+
+func mustRegisterFormat(name, description string, mediaTypes, options []string, factory TranslatorFactory) {
+	if err := RegisterFormat(name, description, mediaTypes, options, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Formats lists registered formats in order of registration.
+func Formats() []FormatInfo {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	result := make([]FormatInfo, len(registry.formats))
+	for k := range registry.formats {
+		result[k] = registry.formats[k].info
+	}
+	return result
+}
+
+// TranslatorByName creates translator of format registered with name, extensions aren't
+// accepted as they may be shared by more formats.
+func TranslatorByName(name string, options url.Values) (GraphTranslator, bool) {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	index, ok := registry.names[name]
+	if !ok {
+		return nil, false
+	}
+	return registry.formats[index].factory(options), true
+}
+
+// formatName returns name of format with the name or extension, unknown names are returned unchanged.
+func formatName(name string) string {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	if _, ok := registry.names[name]; ok {
+		return name
+	}
+	if index, ok := registry.extensions[name]; ok {
+		return registry.formats[index].info.Name
+	}
+	return name
+}
+
+// TranslatorByMediaType creates translator of format with the media type, wildcards
+// like text/* select the first registered format matching them.
+func TranslatorByMediaType(mediaType string, options url.Values) (GraphTranslator, bool) {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	if mediaType == "*/*" {
+		index := registry.names[DefaultFormat]
+		return registry.formats[index].factory(options), true
+	}
+	if prefix, ok := strings.CutSuffix(mediaType, "/*"); ok {
+		for _, format := range registry.formats {
+			for _, candidate := range format.info.MediaTypes {
+				if strings.HasPrefix(candidate, prefix+"/") {
+					return format.factory(options), true
+				}
+			}
+		}
+		return nil, false
+	}
+	index, ok := registry.mediaTypes[mediaType]
+	if !ok {
+		return nil, false
+	}
+	return registry.formats[index].factory(options), true
+}
+
+type acceptedType struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept returns media types of Accept header ordered by preference,
+// types with zero quality are left out.
+func parseAccept(accept string) []acceptedType {
+	var result []acceptedType
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if key != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		if quality > 0 {
+			result = append(result, acceptedType{mediaType: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].quality > result[j].quality
+	})
+	return result
+}
+
+// NegotiateTranslator selects translator for the download. Format named by kind is used
+// when kind is set, otherwise the most preferred acceptable media type is chosen and
+// the default format is used without Accept header.
+// Selected translator fails with ErrInvalidOptions when its options are invalid.
+func NegotiateTranslator(kind, accept string, options url.Values) (GraphTranslator, error) {
+	if kind != "" {
+		if translator, ok := TranslatorByName(kind, options); ok {
+			return validateTranslator(translator)
+		}
+		return nil, ErrUnsupportedFormat
+	}
+	if strings.TrimSpace(accept) == "" {
+		translator, _ := TranslatorByName(DefaultFormat, options)
+		return validateTranslator(translator)
+	}
+	for _, accepted := range parseAccept(accept) {
+		if translator, ok := TranslatorByMediaType(accepted.mediaType, options); ok {
+			return validateTranslator(translator)
+		}
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func validateTranslator(translator GraphTranslator) (GraphTranslator, error) {
+	if vt, ok := translator.(ValidatedTranslator); ok {
+		if err := vt.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return translator, nil
+}
+
+func init() {
+	mustRegisterFormat("matrix", "Adjacency matrix", nil, []string{"names"}, func(options url.Values) GraphTranslator {
+		return &MatrixGraph{Names: options.Get("names") == "true"}
+	})
+	mustRegisterFormat("JSON", "JSON", nil, nil, func(url.Values) GraphTranslator {
+		return &BasicJSONGraph{}
+	})
+	mustRegisterFormat("dot", "Graphviz", []string{"text/vnd.graphviz"}, nil, func(url.Values) GraphTranslator {
+		return &DotGraph{}
+	})
+	mustRegisterFormat("graphml", "GraphML", nil, nil, func(url.Values) GraphTranslator {
+		return &GraphMLGraph{}
+	})
+	mustRegisterFormat("gexf", "GEXF", nil, nil, func(url.Values) GraphTranslator {
+		return &GEXFGraph{}
+	})
+	mustRegisterFormat("edgelist", "Edge list", nil, []string{"header"}, func(options url.Values) GraphTranslator {
+		return &EdgeListGraph{Header: options.Get("header") == "true"}
+	})
+	mustRegisterFormat("csv", "CSV nodes and edges", []string{"text/csv"}, nil, func(url.Values) GraphTranslator {
+		return &CSVGraph{}
+	})
+	mustRegisterFormat("adjlist", "Adjacency list", nil, nil, func(url.Values) GraphTranslator {
+		return &AdjListGraph{}
+	})
+	mustRegisterFormat("dimacs", "DIMACS", nil, nil, func(url.Values) GraphTranslator {
+		return &DIMACSGraph{}
+	})
+	mustRegisterFormat("dimacs-sp", "DIMACS shortest path", nil, nil, func(url.Values) GraphTranslator {
+		return &DIMACSGraph{ShortestPath: true}
+	})
+	mustRegisterFormat("metis", "METIS", nil, nil, func(url.Values) GraphTranslator {
+		return &METISGraph{}
+	})
+	mustRegisterFormat("matrix-market", "Matrix Market", nil, nil, func(url.Values) GraphTranslator {
+		return &MatrixMarketGraph{}
+	})
+	mustRegisterFormat("graph6", "graph6", nil, nil, func(url.Values) GraphTranslator {
+		return &Graph6Graph{}
+	})
+	mustRegisterFormat("sparse6", "sparse6", nil, nil, func(url.Values) GraphTranslator {
+		return &Sparse6Graph{}
+	})
+	mustRegisterFormat("svg", "SVG image", nil, nil, func(url.Values) GraphTranslator {
+		return &SVGGraph{}
+	})
+	mustRegisterFormat("tikz", "TikZ", []string{"text/x-tex"}, []string{"document", "nodeStyle", "weights"},
+		func(options url.Values) GraphTranslator {
+			return &TikZGraph{
+				Document:    options.Get("document") == "true",
+				NodeStyle:   options.Get("nodeStyle"),
+				HideWeights: options.Get("weights") == "false",
+			}
+		})
+	mustRegisterFormat("networkx", "NetworkX node-link", nil, nil, func(url.Values) GraphTranslator {
+		return &NetworkXGraph{}
+	})
+	mustRegisterFormat("cytoscape", "Cytoscape.js", nil, nil, func(url.Values) GraphTranslator {
+		return &CytoscapeGraph{}
+	})
+	mustRegisterFormat("cypher", "Neo4j Cypher script", []string{"application/x-cypher-query"}, nil,
+		func(url.Values) GraphTranslator {
+			return &CypherGraph{}
+		})
+	mustRegisterFormat("sql", "SQL script", nil, nil, func(url.Values) GraphTranslator {
+		return &SQLGraph{}
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+func TestGetGraphRepre(t *testing.T) {
+	for format, name := range graphFormatString {
+		translator := format.GetGraphRepre()
+		if assert.NotNil(t, translator, name) {
+			info, _ := TranslatorByName(name, nil)
+			assert.IsType(t, info, translator)
+		}
+	}
+	assert.IsType(t, &DotGraph{}, Dot.GetGraphRepre())
+}
+
+func TestNegotiateTranslator(t *testing.T) {
+	cases := []struct {
+		kind, accept string
+		expected     GraphTranslator
+	}{
+		{"", "", &MatrixGraph{}},
+		{"dot", "application/json", &DotGraph{}},
+		{"graph6", "", &Graph6Graph{}},
+		{"", "application/json", &BasicJSONGraph{}},
+		{"", "text/html, image/svg+xml;q=0.9, */*;q=0.1", &SVGGraph{}},
+		{"", "application/gexf+xml;q=0.5, text/vnd.graphviz", &DotGraph{}},
+		{"", "text/*", &MatrixGraph{}},
+		{"", "*/*", &MatrixGraph{}},
+		{"", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", &MatrixGraph{}},
+		{"", "application/graphml+xml", &GraphMLGraph{}},
+	}
+	for _, c := range cases {
+		translator, err := NegotiateTranslator(c.kind, c.accept, nil)
+		assert.NoError(t, err, c)
+		assert.IsType(t, c.expected, translator, c)
+	}
+
+	for _, kind := range []string{"g6", "txt", "json"} {
+		_, err := NegotiateTranslator(kind, "", nil)
+		assert.ErrorIs(t, err, ErrUnsupportedFormat, kind)
+	}
+	_, err := NegotiateTranslator("pdf", "", nil)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = NegotiateTranslator("", "application/pdf, text/plain;q=0", nil)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	translator, err := NegotiateTranslator("tikz", "", url.Values{"document": {"true"}})
+	assert.NoError(t, err)
+	assert.True(t, translator.(*TikZGraph).Document)
+	_, err = NegotiateTranslator("tikz", "", url.Values{"nodeStyle": {`draw, label={\input{x}}`}})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	err := RegisterFormat("matrix", "", nil, nil, func(url.Values) GraphTranslator {
+		return &MatrixGraph{}
+	})
+	assert.ErrorIs(t, err, ErrFormatRegistered)
+
+	formats := Formats()
+	assert.Equal(t, DefaultFormat, formats[0].Name)
+	names := make(map[string]FormatInfo)
+	for _, f := range formats {
+		names[f.Name] = f
+	}
+	assert.Len(t, names, len(graphFormatString))
+	assert.Equal(t, "g6", names["graph6"].Extension)
+	assert.True(t, names["graph6"].Concatenable)
+	assert.Contains(t, names["dot"].MediaTypes, "text/vnd.graphviz")
+}
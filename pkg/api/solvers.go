@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formats in this file index nodes from one as the solvers expect.
+
+func (d *DIMACSGraph) Extension() string {
+	if d.ShortestPath {
+		return "gr"
+	}
+	return "col"
+}
+
+func (d *DIMACSGraph) Kind() string {
+	if d.ShortestPath {
+		return "dimacs-sp"
+	}
+	return "dimacs"
+}
+
+func (d *DIMACSGraph) ContentType() string {
+	return "text/plain"
+}
+
+// Convert fails for shortest path format when weights of graph aren't integers, DIMACS
+// accepts integer arc lengths only.
+func (d *DIMACSGraph) Convert(g generator.Graph) bool {
+	d.data = newExportData(g)
+	return d.representable(&d.data) == nil
+}
+
+// Representable fails with ErrUnrepresentable naming the first weight which isn't an integer.
+func (d *DIMACSGraph) Representable(g generator.Graph) error {
+	data := newExportData(g)
+	return d.representable(&data)
+}
+
+func (d *DIMACSGraph) representable(data *exportData) error {
+	if d.ShortestPath && data.weighted {
+		for _, e := range data.edges {
+			if !integral(data.weights[e], math.Inf(-1)) {
+				return fmt.Errorf("%w: DIMACS shortest path accepts integer edge weights only, edge %d-%d has weight %s",
+					ErrUnrepresentable, e.Left, e.Right, data.weight(e))
+			}
+		}
+	}
+	return nil
+}
+
+// Serialize writes the graph in DIMACS clique and coloring format "p edge" with vertex
+// weights on "n" lines, or in shortest path format "p sp" where every edge is written
+// as two opposite arcs with integer length of the edge weight, or one for unweighted graphs.
+func (d *DIMACSGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	data := &d.data
+	builder := strings.Builder{}
+	builder.WriteString("c generated by GraphGenerator\n")
+	if d.ShortestPath {
+		builder.WriteString(fmt.Sprintf("p sp %d %d\n", len(data.labels), 2*len(data.edges)))
+		for _, e := range data.edges {
+			weight := 1
+			if data.weighted {
+				weight = int(data.weights[e])
+			}
+			builder.WriteString(fmt.Sprintf("a %d %d %d\n", e.Left+1, e.Right+1, weight))
+			builder.WriteString(fmt.Sprintf("a %d %d %d\n", e.Right+1, e.Left+1, weight))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("p edge %d %d\n", len(data.labels), len(data.edges)))
+		for k := range data.vertexWeights {
+			builder.WriteString(fmt.Sprintf("n %d %s\n", k+1, data.vertexWeight(k)))
+		}
+		for _, e := range data.edges {
+			builder.WriteString(fmt.Sprintf("e %d %d\n", e.Left+1, e.Right+1))
+		}
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (d *DIMACSGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	d.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func (m *METISGraph) Extension() string {
+	return "graph"
+}
+
+func (m *METISGraph) Kind() string {
+	return "metis"
+}
+
+func (m *METISGraph) ContentType() string {
+	return "text/plain"
+}
+
+// Convert fails for graphs which METIS can't represent, it accepts positive integer weights
+// of edges and non-negative integer weights of vertices only.
+func (m *METISGraph) Convert(g generator.Graph) bool {
+	m.data = newExportData(g)
+	return m.representable(&m.data) == nil
+}
+
+// Representable fails with ErrUnrepresentable naming the first weight METIS doesn't accept.
+func (m *METISGraph) Representable(g generator.Graph) error {
+	data := newExportData(g)
+	return m.representable(&data)
+}
+
+func (m *METISGraph) representable(data *exportData) error {
+	if data.weighted {
+		for _, e := range data.edges {
+			if !integral(data.weights[e], 1) {
+				return fmt.Errorf("%w: METIS accepts positive integer edge weights only, edge %d-%d has weight %s",
+					ErrUnrepresentable, e.Left, e.Right, data.weight(e))
+			}
+		}
+	}
+	for k, v := range data.vertexWeights {
+		if !integral(v, 0) {
+			return fmt.Errorf("%w: METIS accepts non-negative integer vertex weights only, vertex %d has weight %s",
+				ErrUnrepresentable, k, data.vertexWeight(k))
+		}
+	}
+	return nil
+}
+
+// Serialize writes the graph in METIS format, the header contains number of nodes and edges
+// and fmt flags for vertex and edge weights, then one line per node lists its weight and
+// neighbours, each followed by the weight of edge.
+func (m *METISGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	data := &m.data
+	neighbours := make([][]int, len(data.labels))
+	for _, e := range data.edges {
+		neighbours[e.Left] = append(neighbours[e.Left], e.Right)
+		neighbours[e.Right] = append(neighbours[e.Right], e.Left)
+	}
+	for k := range neighbours {
+		sort.Ints(neighbours[k])
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%d %d", len(data.labels), len(data.edges)))
+	vertexWeighted := data.vertexWeights != nil
+	if vertexWeighted || data.weighted {
+		builder.WriteString(" 0")
+		builder.WriteString(strconv.Itoa(boolToInt(vertexWeighted)))
+		builder.WriteString(strconv.Itoa(boolToInt(data.weighted)))
+	}
+	builder.WriteByte('\n')
+	for k := range neighbours {
+		items := make([]string, 0, 2*len(neighbours[k])+1)
+		if vertexWeighted {
+			items = append(items, strconv.Itoa(int(data.vertexWeights[k])))
+		}
+		for _, v := range neighbours[k] {
+			items = append(items, strconv.Itoa(v+1))
+			if data.weighted {
+				weight := data.weights[generator.CreateEdge(k, v)]
+				items = append(items, strconv.Itoa(int(weight)))
+			}
+		}
+		builder.WriteString(strings.Join(items, " "))
+		builder.WriteByte('\n')
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (m *METISGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	m.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func (m *MatrixMarketGraph) Extension() string {
+	return "mtx"
+}
+
+func (m *MatrixMarketGraph) Kind() string {
+	return "matrix-market"
+}
+
+func (m *MatrixMarketGraph) ContentType() string {
+	return "text/plain"
+}
+
+func (m *MatrixMarketGraph) Convert(g generator.Graph) bool {
+	m.data = newExportData(g)
+	return true
+}
+
+// Serialize writes symmetric adjacency matrix in Matrix Market coordinate format, only the
+// lower triangle is stored. Unweighted graphs are written as pattern matrices.
+func (m *MatrixMarketGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	data := &m.data
+	field := "pattern"
+	if data.weighted && data.float {
+		field = "real"
+	} else if data.weighted {
+		field = "integer"
+	}
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%%%%MatrixMarket matrix coordinate %s symmetric\n", field))
+	builder.WriteString("% generated by GraphGenerator\n")
+	builder.WriteString(fmt.Sprintf("%d %d %d\n", len(data.labels), len(data.labels), len(data.edges)))
+	for _, e := range data.edges {
+		builder.WriteString(fmt.Sprintf("%d %d", e.Right+1, e.Left+1))
+		if data.weighted {
+			builder.WriteByte(' ')
+			builder.WriteString(data.weight(e))
+		}
+		builder.WriteByte('\n')
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (m *MatrixMarketGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	m.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// integral reports whether the weight is an integer not smaller than min.
+func integral(weight, min float64) bool {
+	return weight >= min && weight == math.Trunc(weight)
+}
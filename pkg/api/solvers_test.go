@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var trianglePlusNode = generator.SimpleGraph{
+	Size:     4,
+	EdgesMap: []map[int]bool{{1: true, 2: true}, {0: true, 2: true}, {0: true, 1: true}, {}},
+}
+
+// integerTestGraph is path of three nodes with float weights of integer values and vertex weights.
+func integerTestGraph(first, second float64) generator.Graph {
+	path := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	weighted := generator.FloatWeightedGraph{
+		ParentGraph: path,
+		WeightsMap:  map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: first, {Left: 1, Right: 2}: second},
+		Decimals:    1,
+	}
+	return generator.AttributedGraph{ParentGraph: weighted, VertexWeights: []float64{1, 2, 3}}
+}
+
+func TestDIMACS(t *testing.T) {
+	translator := &DIMACSGraph{}
+	assert.True(t, translator.Convert(trianglePlusNode))
+	assert.Equal(t, "c generated by GraphGenerator\np edge 4 3\ne 1 2\ne 1 3\ne 2 3\n", string(translator.Bytes()))
+	assert.Equal(t, "col", translator.Extension())
+
+	translator = &DIMACSGraph{ShortestPath: true}
+	assert.True(t, translator.Convert(integerTestGraph(1, -2)))
+	assert.Equal(t, "c generated by GraphGenerator\np sp 3 4\na 1 2 1\na 2 1 1\na 2 3 -2\na 3 2 -2\n", string(translator.Bytes()))
+	assert.False(t, translator.Convert(attributedTestGraph()))
+	err := translator.Representable(attributedTestGraph())
+	assert.ErrorIs(t, err, ErrUnrepresentable)
+	assert.Contains(t, err.Error(), "edge 0-1 has weight 1.5")
+
+	translator = &DIMACSGraph{}
+	translator.Convert(attributedTestGraph())
+	assert.Contains(t, string(translator.Bytes()), "n 1 1\nn 2 2\nn 3 3\n")
+}
+
+func TestMETIS(t *testing.T) {
+	translator := &METISGraph{}
+	assert.True(t, translator.Convert(trianglePlusNode))
+	assert.Equal(t, "4 3\n2 3\n1 3\n1 2\n\n", string(translator.Bytes()))
+
+	assert.True(t, translator.Convert(integerTestGraph(1, 2)))
+	assert.Equal(t, "3 2 011\n1 2 1\n2 1 1 3 2\n3 2 2\n", string(translator.Bytes()))
+
+	assert.False(t, translator.Convert(attributedTestGraph()))
+	assert.False(t, translator.Convert(integerTestGraph(1, -2)))
+	assert.False(t, translator.Convert(integerTestGraph(0, 2)))
+	assert.ErrorIs(t, translator.Representable(integerTestGraph(0, 2)), ErrUnrepresentable)
+	assert.NoError(t, translator.Representable(integerTestGraph(1, 2)))
+	assert.False(t, translator.Convert(generator.AttributedGraph{ParentGraph: trianglePlusNode, VertexWeights: []float64{1, -1, 0, 2}}))
+}
+
+func TestMatrixMarket(t *testing.T) {
+	translator := &MatrixMarketGraph{}
+	assert.True(t, translator.Convert(trianglePlusNode))
+	assert.Equal(t, "%%MatrixMarket matrix coordinate pattern symmetric\n% generated by GraphGenerator\n4 4 3\n2 1\n3 1\n3 2\n", string(translator.Bytes()))
+
+	translator.Convert(attributedTestGraph())
+	assert.Equal(t, "%%MatrixMarket matrix coordinate real symmetric\n% generated by GraphGenerator\n3 3 2\n2 1 1.5\n3 2 -2.0\n", string(translator.Bytes()))
+}
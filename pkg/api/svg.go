@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	svgSize   = 800
+	svgMargin = 40
+)
+
+func (s *SVGGraph) Extension() string {
+	return "svg"
+}
+
+func (s *SVGGraph) Kind() string {
+	return "svg"
+}
+
+func (s *SVGGraph) ContentType() string {
+	return "image/svg+xml"
+}
+
+func (s *SVGGraph) Convert(g generator.Graph) bool {
+	s.data = newExportData(g)
+	s.positions = fitLayout(computeLayout(&s.data), svgSize, svgSize, svgMargin)
+	return true
+}
+
+func svgText(text string) string {
+	builder := strings.Builder{}
+	xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
+
+// Serialize draws edges with weight labels in their middle and nodes labeled by their names.
+func (s *SVGGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	d := &s.data
+	radius := math.Max(3, math.Min(12, 300/math.Sqrt(float64(len(d.labels)+1))))
+	builder := strings.Builder{}
+	builder.WriteString(xml.Header)
+	builder.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		svgSize, svgSize, svgSize, svgSize))
+	builder.WriteString(`<rect width="100%" height="100%" fill="white"/>` + "\n")
+
+	builder.WriteString(`<g stroke="#555" stroke-width="1.5">` + "\n")
+	for _, e := range d.edges {
+		from, to := s.positions[e.Left], s.positions[e.Right]
+		builder.WriteString(fmt.Sprintf(`<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f"/>`+"\n", from.X, from.Y, to.X, to.Y))
+	}
+	builder.WriteString("</g>\n")
+
+	if d.weighted {
+		builder.WriteString(`<g font-family="sans-serif" font-size="11" fill="#a00" text-anchor="middle">` + "\n")
+		for _, e := range d.edges {
+			from, to := s.positions[e.Left], s.positions[e.Right]
+			builder.WriteString(fmt.Sprintf(`<text x="%.2f" y="%.2f">%s</text>`+"\n",
+				(from.X+to.X)/2, (from.Y+to.Y)/2-3, svgText(d.weight(e))))
+		}
+		builder.WriteString("</g>\n")
+	}
+
+	builder.WriteString(`<g font-family="sans-serif" text-anchor="middle" dominant-baseline="central">` + "\n")
+	for k, p := range s.positions {
+		builder.WriteString(fmt.Sprintf(`<circle cx="%.2f" cy="%.2f" r="%.2f" fill="#9cf" stroke="#036"/>`+"\n", p.X, p.Y, radius))
+		builder.WriteString(fmt.Sprintf(`<text x="%.2f" y="%.2f" font-size="%.2f">%s</text>`+"\n", p.X, p.Y, radius, svgText(d.labels[k])))
+	}
+	builder.WriteString("</g>\n</svg>\n")
+
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (s *SVGGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	s.Serialize(&buffer)
+	return buffer.Bytes()
+}
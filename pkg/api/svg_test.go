@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/xml"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func checkInsideBox(t *testing.T, positions []point) {
+	for _, p := range positions {
+		assert.True(t, p.X >= svgMargin-1e-9 && p.X <= svgSize-svgMargin+1e-9)
+		assert.True(t, p.Y >= svgMargin-1e-9 && p.Y <= svgSize-svgMargin+1e-9)
+	}
+}
+
+func TestSVGLayouts(t *testing.T) {
+	cycle := graphFromEdges(6, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 0}})
+	translator := &SVGGraph{}
+	assert.True(t, translator.Convert(cycle))
+	checkInsideBox(t, translator.positions)
+	center := point{X: svgSize / 2, Y: svgSize / 2}
+	radius := math.Hypot(translator.positions[0].X-center.X, translator.positions[0].Y-center.Y)
+	for _, p := range translator.positions {
+		assert.InDelta(t, radius, math.Hypot(p.X-center.X, p.Y-center.Y), 1e-6)
+	}
+
+	star := graphFromEdges(5, [][2]int{{0, 1}, {0, 2}, {0, 3}, {0, 4}})
+	translator.Convert(star)
+	checkInsideBox(t, translator.positions)
+	first := translator.positions
+	translator.Convert(star)
+	assert.Equal(t, first, translator.positions)
+
+	positions := []generator.Attributes{
+		{PositionXAttribute: generator.IntAttr(0), PositionYAttribute: generator.IntAttr(0)},
+		{PositionXAttribute: generator.FloatAttr(2), PositionYAttribute: generator.IntAttr(0)},
+	}
+	path := graphFromEdges(2, [][2]int{{0, 1}})
+	translator.Convert(generator.AttributedGraph{ParentGraph: path, VertexAttributes: positions})
+	assert.Equal(t, []point{{X: svgMargin, Y: svgSize / 2}, {X: svgSize - svgMargin, Y: svgSize / 2}}, translator.positions)
+}
+
+func TestSVGDocument(t *testing.T) {
+	translator := &SVGGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := translator.Bytes()
+	var doc struct {
+		XMLName xml.Name `xml:"svg"`
+		Groups  []struct {
+			Lines   []struct{} `xml:"line"`
+			Circles []struct{} `xml:"circle"`
+			Texts   []string   `xml:"text"`
+		} `xml:"g"`
+	}
+	assert.Nil(t, xml.Unmarshal(out, &doc))
+	assert.Len(t, doc.Groups, 3)
+	assert.Len(t, doc.Groups[0].Lines, 2)
+	assert.Equal(t, []string{"1.5", "-2.0"}, doc.Groups[1].Texts)
+	assert.Len(t, doc.Groups[2].Circles, 3)
+	assert.Equal(t, []string{"a", "b", "c"}, doc.Groups[2].Texts)
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// textLabel returns label usable as one whitespace separated token, labels
+// containing whitespace or quotes are quoted.
+func textLabel(label string) string {
+	if label == "" || strings.ContainsAny(label, " \t\n\r\"") {
+		return strconv.Quote(label)
+	}
+	return label
+}
+
+func (e *EdgeListGraph) Extension() string {
+	return "edges"
+}
+
+func (e *EdgeListGraph) Kind() string {
+	return "edgelist"
+}
+
+func (e *EdgeListGraph) ContentType() string {
+	return "text/plain"
+}
+
+func (e *EdgeListGraph) Convert(g generator.Graph) bool {
+	e.data = newExportData(g)
+	return true
+}
+
+// Serialize writes one edge per line as "u v" followed by the weight for weighted graphs,
+// the optional header line contains number of nodes and edges.
+func (e *EdgeListGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	d := &e.data
+	builder := strings.Builder{}
+	if e.Header {
+		builder.WriteString(fmt.Sprintf("%d %d\n", len(d.labels), len(d.edges)))
+	}
+	for _, edge := range d.edges {
+		builder.WriteString(textLabel(d.labels[edge.Left]))
+		builder.WriteByte(' ')
+		builder.WriteString(textLabel(d.labels[edge.Right]))
+		if d.weighted {
+			builder.WriteByte(' ')
+			builder.WriteString(d.weight(edge))
+		}
+		builder.WriteByte('\n')
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (e *EdgeListGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	e.Serialize(&buffer)
+	return buffer.Bytes()
+}
+
+func (a *AdjListGraph) Extension() string {
+	return "adjlist"
+}
+
+func (a *AdjListGraph) Kind() string {
+	return "adjlist"
+}
+
+func (a *AdjListGraph) ContentType() string {
+	return "text/plain"
+}
+
+func (a *AdjListGraph) Convert(g generator.Graph) bool {
+	a.labels = nodeLabels(g)
+	a.neighbours = make([][]int, len(g.Edges()))
+	for k := range g.Edges() {
+		a.neighbours[k] = make([]int, 0, len(g.Edges()[k]))
+		for j, ok := range g.Edges()[k] {
+			if ok {
+				a.neighbours[k] = append(a.neighbours[k], j)
+			}
+		}
+		sort.Ints(a.neighbours[k])
+	}
+	return true
+}
+
+// Serialize writes one line per node, the node is followed by all its neighbours.
+func (a *AdjListGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	builder := strings.Builder{}
+	for k, neighbours := range a.neighbours {
+		builder.WriteString(textLabel(a.labels[k]))
+		for _, v := range neighbours {
+			builder.WriteByte(' ')
+			builder.WriteString(textLabel(a.labels[v]))
+		}
+		builder.WriteByte('\n')
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (a *AdjListGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	a.Serialize(&buffer)
+	return buffer.Bytes()
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEdgeList(t *testing.T) {
+	translator := &EdgeListGraph{Header: true}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	assert.Equal(t, "3 2\na b 1.5\nb c -2.0\n", string(translator.Bytes()))
+
+	translator = &EdgeListGraph{}
+	path := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	translator.Convert(generator.NamedGraph{ParentGraph: path, VertexNames: []string{"New York", "b", "c"}})
+	assert.Equal(t, "\"New York\" b\nb c\n", string(translator.Bytes()))
+}
+
+func TestAdjList(t *testing.T) {
+	translator := &AdjListGraph{}
+	path := generator.SimpleGraph{Size: 4, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}, {}}}
+	assert.True(t, translator.Convert(path))
+	assert.Equal(t, "0 1\n1 0 2\n2 1\n3\n", string(translator.Bytes()))
+}
+
+func TestCSV(t *testing.T) {
+	translator := &CSVGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := translator.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	assert.Nil(t, err)
+	assert.Len(t, archive.File, 2)
+
+	records := make(map[string][][]string)
+	for _, f := range archive.File {
+		reader, err := f.Open()
+		assert.Nil(t, err)
+		records[f.Name], err = csv.NewReader(reader).ReadAll()
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, [][]string{
+		{"id", "label", "color", "weight"},
+		{"0", "a", "red & blue", "1"},
+		{"1", "b", "", "2"},
+		{"2", "c", "", "3"},
+	}, records["nodes.csv"])
+	assert.Equal(t, [][]string{
+		{"source", "target", "weight", "capacity"},
+		{"0", "1", "1.5", ""},
+		{"1", "2", "-2.0", "4"},
+	}, records["edges.csv"])
+}
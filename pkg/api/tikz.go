@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"io"
+	"strings"
+)
+
+// DefaultTikZNodeStyle is TikZ style of vertices used when no other is configured.
+const DefaultTikZNodeStyle = "draw, circle, fill=white, minimum size=6mm, inner sep=1pt"
+
+// tikzSize is the size of the picture in centimetres.
+const tikzSize = 8
+
+var latexEscapes = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`#`, `\#`,
+	`$`, `\$`,
+	`%`, `\%`,
+	`&`, `\&`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`)
+
+func (t *TikZGraph) Extension() string {
+	return "tex"
+}
+
+func (t *TikZGraph) Kind() string {
+	return "tikz"
+}
+
+func (t *TikZGraph) ContentType() string {
+	return "application/x-tex"
+}
+
+// tikzStyleSymbols are the characters besides letters and digits permitted in node style,
+// they suffice for TikZ options like "fill=red!20, minimum size=6mm" but can't execute commands.
+const tikzStyleSymbols = " ,=.!+-*/()"
+
+// validNodeStyle checks that style contains only letters, digits and tikzStyleSymbols,
+// so it can be inserted into the document unescaped.
+func validNodeStyle(style string) bool {
+	for _, c := range style {
+		letter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !letter && !strings.ContainsRune(tikzStyleSymbols, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate fails with ErrInvalidOptions when NodeStyle contains characters which aren't permitted.
+func (t *TikZGraph) Validate() error {
+	if !validNodeStyle(t.NodeStyle) {
+		return fmt.Errorf("%w: nodeStyle may contain only letters, digits and %q", ErrInvalidOptions, tikzStyleSymbols)
+	}
+	return nil
+}
+
+// Convert computes the same deterministic layout as the SVG output, it fails when NodeStyle
+// contains characters which aren't permitted.
+func (t *TikZGraph) Convert(g generator.Graph) bool {
+	if !validNodeStyle(t.NodeStyle) {
+		return false
+	}
+	t.data = newExportData(g)
+	t.positions = fitLayout(computeLayout(&t.data), tikzSize, tikzSize, 0)
+	return true
+}
+
+// Serialize writes tikzpicture with nodes named n0, n1, ... and edges between them, weights are
+// written as labels in the middle of edges unless hidden. Document wraps the picture into
+// standalone LaTeX document.
+func (t *TikZGraph) Serialize(writer io.Writer) (io.Writer, error) {
+	d := &t.data
+	style := t.NodeStyle
+	if style == "" {
+		style = DefaultTikZNodeStyle
+	}
+	builder := strings.Builder{}
+	if t.Document {
+		builder.WriteString("\\documentclass[tikz,border=2mm]{standalone}\n\\begin{document}\n")
+	}
+	builder.WriteString(fmt.Sprintf("\\begin{tikzpicture}[vertex/.style={%s}, weight/.style={midway, fill=white, inner sep=1pt, font=\\small}]\n", style))
+	for k, p := range t.positions {
+		// TikZ y axis points up
+		builder.WriteString(fmt.Sprintf("  \\node[vertex] (n%d) at (%.2f, %.2f) {%s};\n", k, p.X, tikzSize-p.Y, latexEscapes.Replace(d.labels[k])))
+	}
+	for _, e := range d.edges {
+		if d.weighted && !t.HideWeights {
+			builder.WriteString(fmt.Sprintf("  \\draw (n%d) -- node[weight] {%s} (n%d);\n", e.Left, d.weight(e), e.Right))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("  \\draw (n%d) -- (n%d);\n", e.Left, e.Right))
+	}
+	builder.WriteString("\\end{tikzpicture}\n")
+	if t.Document {
+		builder.WriteString("\\end{document}\n")
+	}
+	_, err := writer.Write([]byte(builder.String()))
+	return writer, err
+}
+
+func (t *TikZGraph) Bytes() []byte {
+	buffer := bytes.Buffer{}
+	t.Serialize(&buffer)
+	return buffer.Bytes()
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestTikZ(t *testing.T) {
+	translator := &TikZGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := string(translator.Bytes())
+	assert.True(t, strings.HasPrefix(out, "\\begin{tikzpicture}[vertex/.style={"+DefaultTikZNodeStyle+"}"))
+	assert.Contains(t, out, "\\draw (n0) -- node[weight] {1.5} (n1);\n")
+	assert.Equal(t, 3, strings.Count(out, "\\node[vertex]"))
+	assert.Equal(t, out, string(translator.Bytes()))
+
+	translator = &TikZGraph{Document: true, NodeStyle: "draw", HideWeights: true}
+	path := graphFromEdges(2, [][2]int{{0, 1}})
+	translator.Convert(generator.NamedGraph{ParentGraph: path, VertexNames: []string{"a_1", "50%"}})
+	out = string(translator.Bytes())
+	assert.True(t, strings.HasPrefix(out, "\\documentclass[tikz,border=2mm]{standalone}\n\\begin{document}\n"))
+	assert.True(t, strings.HasSuffix(out, "\\end{tikzpicture}\n\\end{document}\n"))
+	assert.Contains(t, out, "[vertex/.style={draw}")
+	assert.Contains(t, out, "{a\\_1};")
+	assert.Contains(t, out, "{50\\%};")
+	assert.Contains(t, out, "\\draw (n0) -- (n1);\n")
+
+	for _, style := range []string{`draw, label={\input{/etc/passwd}}`, "draw]", "fill=red\\", "draw}{"} {
+		translator = &TikZGraph{NodeStyle: style}
+		assert.False(t, translator.Convert(path), style)
+	}
+	translator = &TikZGraph{NodeStyle: "draw, fill=red!20, minimum size=6mm"}
+	assert.True(t, translator.Convert(path))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"io"
 	"time"
@@ -13,6 +14,11 @@ var (
 	ErrInvalidGraphFormat   = errors.New("invalid string for graphFormat")
 	ErrInvalidGraphType     = errors.New("invalid graph type passed")
 	ErrInvalidRequestStatus = errors.New("invalid graph status passed")
+	ErrInvalidOperation     = errors.New("invalid graph operation passed")
+	ErrInvalidDistribution  = errors.New("invalid weight distribution passed")
+	ErrInvalidWeightMode    = errors.New("invalid weight mode passed")
+	ErrNegativeCyclesMode   = errors.New("no-negative-cycles mode isn't supported, negative edge of undirected graph forms negative cycle with its reverse")
+	ErrInvalidNaming        = errors.New("invalid naming scheme passed")
 )
 
 type GraphTranslator interface {
@@ -24,9 +30,65 @@ type GraphTranslator interface {
 	Kind() string
 }
 
+// ValidatedTranslator is translator whose options may be invalid, they are checked
+// when the format is selected so the download is refused before it begins.
+type ValidatedTranslator interface {
+	GraphTranslator
+	Validate() error
+}
+
+// RepresentableTranslator is translator of format which can't represent every graph,
+// Representable explains why the graph can't be converted so the download is refused
+// before it begins.
+type RepresentableTranslator interface {
+	GraphTranslator
+	Representable(g generator.Graph) error
+}
+
+// ConcatTranslator is translator whose outputs of multiple graphs may be concatenated,
+// so the whole batch can be downloaded as one file.
+type ConcatTranslator interface {
+	GraphTranslator
+	Concatenable() bool
+}
+
+// GraphResult is the generated graph, Solutions answer the problems listed by its request.
+// Verification is the reason why the graph doesn't satisfy its request, it is empty for correct graphs.
+// Error is set instead of the graph when the generation failed.
 type GraphResult struct {
-	ID        uint32
-	Generated generator.Graph
+	ID           uint32
+	Generated    generator.Graph
+	Solutions    []analysis.Solution
+	Verification string
+	Error        string
+}
+
+// Status returns status of request after the result is stored.
+func (r *GraphResult) Status() RequestStatus {
+	switch {
+	case r.Error != "":
+		return Failed
+	case r.Verification != "":
+		return VerificationFailed
+	}
+	return Finished
+}
+
+// StatusError returns the message stored with the status of request.
+func (r *GraphResult) StatusError() string {
+	if r.Error != "" {
+		return r.Error
+	}
+	return r.Verification
+}
+
+// Size returns number of nodes of the generated graph, which differs from the requested
+// number when operations were applied. It is zero when no graph was generated.
+func (r *GraphResult) Size() int {
+	if r.Generated == nil {
+		return 0
+	}
+	return len(r.Generated.Edges())
 }
 
 type GraphFormat uint8
@@ -35,17 +97,68 @@ const (
 	JSON GraphFormat = iota
 	Matrix
 	Dot
+	GraphML
+	GEXF
+	EdgeList
+	CSV
+	AdjList
+	DIMACS
+	DIMACSShortestPath
+	METIS
+	MatrixMarket
+	Graph6
+	Sparse6
+	SVG
+	TikZ
+	NetworkX
+	Cytoscape
+	Cypher
+	SQL
 )
 
 var graphFormatString = map[GraphFormat]string{
-	JSON:   "JSON",
-	Dot:    "dot",
-	Matrix: "matrix"}
+	JSON:               "JSON",
+	Dot:                "dot",
+	Matrix:             "matrix",
+	GraphML:            "graphml",
+	GEXF:               "gexf",
+	EdgeList:           "edgelist",
+	CSV:                "csv",
+	AdjList:            "adjlist",
+	DIMACS:             "dimacs",
+	DIMACSShortestPath: "dimacs-sp",
+	METIS:              "metis",
+	MatrixMarket:       "matrix-market",
+	Graph6:             "graph6",
+	Sparse6:            "sparse6",
+	SVG:                "svg",
+	TikZ:               "tikz",
+	NetworkX:           "networkx",
+	Cytoscape:          "cytoscape",
+	Cypher:             "cypher",
+	SQL:                "sql"}
 
 var stringGraphFormat = map[string]GraphFormat{
-	"JSON":   JSON,
-	"dot":    Dot,
-	"matrix": Matrix}
+	"JSON":          JSON,
+	"dot":           Dot,
+	"matrix":        Matrix,
+	"graphml":       GraphML,
+	"gexf":          GEXF,
+	"edgelist":      EdgeList,
+	"csv":           CSV,
+	"adjlist":       AdjList,
+	"dimacs":        DIMACS,
+	"dimacs-sp":     DIMACSShortestPath,
+	"metis":         METIS,
+	"matrix-market": MatrixMarket,
+	"graph6":        Graph6,
+	"sparse6":       Sparse6,
+	"svg":           SVG,
+	"tikz":          TikZ,
+	"networkx":      NetworkX,
+	"cytoscape":     Cytoscape,
+	"cypher":        Cypher,
+	"sql":           SQL}
 
 func (p GraphFormat) String() string {
 	return graphFormatString[p]
@@ -73,14 +186,10 @@ func (p *GraphFormat) UnmarshalJSON(in []byte) error {
 	return nil
 }
 
+// GetGraphRepre creates translator of the format with default options.
 func (p GraphFormat) GetGraphRepre() GraphTranslator {
-	switch p {
-	case Matrix:
-		return &MatrixGraph{}
-	case JSON:
-		return &BasicJSONGraph{}
-	}
-	return nil
+	translator, _ := TranslatorByName(p.String(), nil)
+	return translator
 }
 
 type GraphType uint8
@@ -91,6 +200,7 @@ const (
 	BetweenDeg
 	AverageDeg
 	Complete
+	Imported
 )
 
 var graphToString = map[GraphType]string{
@@ -98,14 +208,16 @@ var graphToString = map[GraphType]string{
 	AtLeastDeg: "at-least-degree",
 	BetweenDeg: "between-degree",
 	AverageDeg: "average-degree",
-	Complete:   "complete"}
+	Complete:   "complete",
+	Imported:   "imported"}
 
 var stringToGraph = map[string]GraphType{
 	"exact-degree":    ExactDeg,
 	"at-least-degree": AtLeastDeg,
 	"between-degree":  BetweenDeg,
 	"average-degree":  AverageDeg,
-	"complete":        Complete}
+	"complete":        Complete,
+	"imported":        Imported}
 
 func (g GraphType) String() string {
 	return graphToString[g]
@@ -143,24 +255,45 @@ const (
 	Undefined RequestStatus = iota
 	NotFinished
 	Finished
+	VerificationFailed
+	Failed
+	Cancelled
 )
 
 var statusToString = map[RequestStatus]string{
-	Undefined:   "undefined",
-	NotFinished: "not-finished",
-	Finished:    "finished",
+	Undefined:          "undefined",
+	NotFinished:        "not-finished",
+	Finished:           "finished",
+	VerificationFailed: "verification-failed",
+	Failed:             "failed",
+	Cancelled:          "cancelled",
 }
 
 var stringToStatus = map[string]RequestStatus{
-	"undefined":    Undefined,
-	"not-finished": NotFinished,
-	"finished":     Finished,
+	"undefined":           Undefined,
+	"not-finished":        NotFinished,
+	"finished":            Finished,
+	"verification-failed": VerificationFailed,
+	"failed":              Failed,
+	"cancelled":           Cancelled,
 }
 
 func (s RequestStatus) String() string {
 	return statusToString[s]
 }
 
+// Generated reports whether the graph of request is stored, graphs which failed
+// the verification are kept so they can be inspected.
+func (s RequestStatus) Generated() bool {
+	return s == Finished || s == VerificationFailed
+}
+
+// Done reports whether the request won't change anymore, failed and cancelled
+// requests are done although they have no graph.
+func (s RequestStatus) Done() bool {
+	return s.Generated() || s == Failed || s == Cancelled
+}
+
 func (s RequestStatus) MarshalJSON() ([]byte, error) {
 	buffer := bytes.Buffer{}
 	buffer.WriteByte('"')
@@ -187,38 +320,75 @@ func (s *RequestStatus) UnmarshalJSON(i []byte) error {
 	return nil
 }
 
+// GraphRequest describes graph to generate. Nodes is the size of the generated base graph,
+// GeneratedNodes is the size of the stored graph, which differs when operations change it.
 type GraphRequest struct {
-	Type              GraphType     `json:"type"`
-	Weighted          bool          `json:"weighted"`
-	Nodes             int           `json:"nodes"`
-	NodeDegree        int           `json:"node_degree"`
-	Status            RequestStatus `json:"status"`
-	Seed              *int64        `json:"seed,omitempty"`
-	Timeout           time.Time     `json:"deleted,omitempty"`
-	NodeDegreeMax     int           `json:"node_degree_max,omitempty"`
-	NodeDegreeAverage float32       `json:"node_degree_average,omitempty"`
-	WeightMin         int           `json:"weight_min"`
-	WeightMax         int           `json:"weight_max"`
-	Connected         bool          `json:"connected"`
-	ID                uint32        `json:"id"`
-	Owner             *string       `json:"-"`
-	BatchId           *uint32       `json:"-"`
+	Type               GraphType           `json:"type"`
+	Weighted           bool                `json:"weighted"`
+	Nodes              int                 `json:"nodes"`
+	NodeDegree         int                 `json:"node_degree"`
+	Status             RequestStatus       `json:"status"`
+	Seed               *int64              `json:"seed,omitempty"`
+	Timeout            time.Time           `json:"deleted,omitempty"`
+	NodeDegreeMax      int                 `json:"node_degree_max,omitempty"`
+	NodeDegreeAverage  float32             `json:"node_degree_average,omitempty"`
+	WeightMin          int                 `json:"weight_min"`
+	WeightMax          int                 `json:"weight_max"`
+	WeightDistribution *WeightDistribution `json:"weight_distribution,omitempty"`
+	AllowZero          bool                `json:"allow_zero,omitempty"`
+	FloatWeights       bool                `json:"float_weights,omitempty"`
+	WeightPrecision    int                 `json:"weight_precision,omitempty"`
+	WeightMode         WeightMode          `json:"weight_mode,omitempty"`
+	VertexWeighted     bool                `json:"vertex_weighted,omitempty"`
+	Naming             *VertexNaming       `json:"naming,omitempty"`
+	Connected          bool                `json:"connected"`
+	MixingSteps        int                 `json:"mixing_steps,omitempty"`
+	Uniform            bool                `json:"uniform,omitempty"`
+	Operations         []GraphOperation    `json:"operations,omitempty"`
+	Solutions          []string            `json:"solutions,omitempty"`
+	Error              string              `json:"error,omitempty"`
+	GeneratedNodes     int                 `json:"generated_nodes,omitempty"`
+	ID                 uint32              `json:"id"`
+	Owner              *string             `json:"-"`
+	BatchId            *uint32             `json:"-"`
 }
 
 type GraphBatchStatus struct {
 	GraphId uint32        `json:"graph_id"`
 	Status  RequestStatus `json:"status"`
+	Error   string        `json:"error,omitempty"`
 }
 
 type BatchRequest struct {
 	BaseGraph GraphRequest  `json:"base"`
 	Number    int           `json:"number"`
+	Enumerate bool          `json:"enumerate,omitempty"`
 	ID        uint32        `json:"id,omitempty"`
 	Timeout   time.Time     `json:"deleted,omitempty"`
 	GraphsIDs []uint32      `json:"graph_ids,omitempty"`
 	Status    RequestStatus `json:"status,omitempty"`
-	Owner     *string       `json:"-"`
-	Finished  int           `json:"-"`
+	// Failures lists graphs of batch which weren't generated or failed the verification.
+	Failures []GraphBatchStatus `json:"failures,omitempty"`
+	Owner    *string            `json:"-"`
+	Finished int                `json:"-"`
+}
+
+// BatchManifest describes the files of downloaded batch archive.
+type BatchManifest struct {
+	BatchID uint32          `json:"batch_id"`
+	Formats []string        `json:"formats"`
+	Archive string          `json:"archive"`
+	Graphs  []ManifestEntry `json:"graphs"`
+}
+
+// ManifestEntry describes one file of batch archive, checksum is SHA-256 of the file.
+type ManifestEntry struct {
+	ID       uint32        `json:"id"`
+	Format   string        `json:"format"`
+	File     string        `json:"file"`
+	Seed     *int64        `json:"seed,omitempty"`
+	Checksum string        `json:"checksum"`
+	Request  *GraphRequest `json:"request,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -234,22 +404,122 @@ type LimitsResponse struct {
 type JSONGraph interface {
 }
 
+// BasicJSONGraph is versioned JSON document with the graph, its properties
+// and the request with seed the graph was generated from, if known.
 type BasicJSONGraph struct {
-	Nodes []string            `json:"nodes"`
-	Edges map[string][]string `json:"edges"`
-}
-
-type WeightedJSONGraph struct {
-	Nodes []string `json:"nodes"`
-	Edges map[string]map[string]int
+	Version    int                 `json:"version"`
+	Request    *GraphRequest       `json:"request,omitempty"`
+	Seed       *int64              `json:"seed,omitempty"`
+	Properties JSONGraphProperties `json:"properties"`
+	Nodes      []JSONNode          `json:"nodes"`
+	Edges      []JSONEdge          `json:"edges"`
 }
 
 type MatrixGraph struct {
-	edges [][]int
+	Names       bool
+	labels      []string
+	edges       [][]float64
+	precision   int
+	vertexAttrs []generator.Attributes
+	edgeAttrs   map[generator.WeightedEdge]generator.Attributes
 }
 
 type DotGraph struct {
-	weighted bool
-	size     int
-	edges    map[generator.WeightedEdge]int
+	labels      []string
+	weighted    bool
+	size        int
+	precision   int
+	edges       map[generator.WeightedEdge]float64
+	vertexAttrs []generator.Attributes
+	edgeAttrs   map[generator.WeightedEdge]generator.Attributes
+}
+
+type GraphMLGraph struct {
+	data exportData
+}
+
+type GEXFGraph struct {
+	data exportData
+}
+
+// EdgeListGraph lists edges one per line, Header adds line with number of nodes and edges.
+type EdgeListGraph struct {
+	Header bool
+	data   exportData
+}
+
+type CSVGraph struct {
+	data exportData
+}
+
+type AdjListGraph struct {
+	labels     []string
+	neighbours [][]int
+}
+
+// DIMACSGraph writes "p edge" format, or "p sp" format if ShortestPath is set.
+type DIMACSGraph struct {
+	ShortestPath bool
+	data         exportData
+}
+
+type METISGraph struct {
+	data exportData
+}
+
+type MatrixMarketGraph struct {
+	data exportData
+}
+
+type Graph6Graph struct {
+	line []byte
+}
+
+type Sparse6Graph struct {
+	line []byte
+}
+
+type SVGGraph struct {
+	data      exportData
+	positions []point
+}
+
+// TikZGraph writes tikzpicture, Document makes it compilable standalone document. NodeStyle
+// replaces DefaultTikZNodeStyle, it is restricted to letters, digits and few symbols since it is
+// written unescaped. HideWeights omits labels with weights of edges.
+type TikZGraph struct {
+	Document    bool
+	NodeStyle   string
+	HideWeights bool
+	data        exportData
+	positions   []point
+}
+
+type NetworkXGraph struct {
+	Directed   bool             `json:"directed"`
+	Multigraph bool             `json:"multigraph"`
+	Graph      map[string]any   `json:"graph"`
+	Nodes      []map[string]any `json:"nodes"`
+	Links      []map[string]any `json:"links"`
+}
+
+type CytoscapeElement struct {
+	Data map[string]any `json:"data"`
+}
+
+type CytoscapeGraph struct {
+	Elements struct {
+		Nodes []CytoscapeElement `json:"nodes"`
+		Edges []CytoscapeElement `json:"edges"`
+	} `json:"elements"`
+}
+
+type CypherGraph struct {
+	ids  scriptIDs
+	data exportData
+}
+
+type SQLGraph struct {
+	ids  scriptIDs
+	data exportData
 }
@@ -1,6 +1,20 @@
 package api
 
-import "github.com/soch-fit/GraphGenerator/pkg/configuration"
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+)
+
+// MaxWeightPrecision is the largest number of decimal places of float weights.
+const MaxWeightPrecision = 10
+
+// MaxEnumerationNodes is the largest graph size for which the enumeration of all
+// non-isomorphic graphs is permitted.
+const MaxEnumerationNodes = 10
+
+// MaxMixingFactor is multiplied by the number of edges to obtain the largest permitted number
+// of switching steps, it is ten times the factor used for the default number of steps.
+const MaxMixingFactor = 100
 
 func (g *GraphRequest) validExactDeg() bool {
 	return (g.Nodes*g.NodeDegree)%2 == 0 && g.NodeDegree > 0 && g.NodeDegree < g.Nodes
@@ -23,7 +37,50 @@ func (g *GraphRequest) validAtLeastDeg() bool {
 }
 
 func (g *GraphRequest) validWeight() bool {
-	return g.WeightMin <= g.WeightMax && !(g.WeightMin == 0 && g.WeightMax == 0)
+	return g.WeightMin <= g.WeightMax && (g.AllowZero || !(g.WeightMin == 0 && g.WeightMax == 0))
+}
+
+func (g *GraphRequest) validPrecision() bool {
+	return g.WeightPrecision >= 0 && g.WeightPrecision <= MaxWeightPrecision
+}
+
+// maxEdges returns the largest number of edges which the graph of request can have.
+func (g *GraphRequest) maxEdges() int {
+	edges := g.Nodes * (g.Nodes - 1) / 2
+	switch g.Type {
+	case ExactDeg:
+		edges = g.Nodes * g.NodeDegree / 2
+	case BetweenDeg:
+		edges = g.Nodes * g.NodeDegreeMax / 2
+	case AverageDeg:
+		edges = int(float32(g.Nodes) * g.NodeDegreeAverage / 2)
+		if edges < g.Nodes-1 {
+			edges = g.Nodes - 1
+		}
+	}
+	if edges < 1 {
+		return 1
+	}
+	return edges
+}
+
+// validMixing limits the number of switching steps relative to the size of graph,
+// every step of connected graph traverses whole graph.
+func (g *GraphRequest) validMixing() bool {
+	return g.MixingSteps >= 0 && g.MixingSteps <= MaxMixingFactor*g.maxEdges()
+}
+
+// validSolutions checks that the requested problems are known and listed once,
+// sources out of the generated graph are reported in the solution.
+func (g *GraphRequest) validSolutions() bool {
+	seen := make(map[string]bool, len(g.Solutions))
+	for _, problem := range g.Solutions {
+		if _, _, err := analysis.ParseProblem(problem); err != nil || seen[problem] {
+			return false
+		}
+		seen[problem] = true
+	}
+	return true
 }
 
 func (g *GraphRequest) validConnected() bool {
@@ -49,14 +106,36 @@ func (g *GraphRequest) Valid() (result bool) {
 		result = result && g.validBetweenDeg()
 	case AtLeastDeg:
 		result = result && g.validAtLeastDeg()
+	case Imported:
+		// imported graphs can't be generated
+		return false
 	}
 
 	if g.Weighted {
-		result = result && g.validWeight()
+		result = result && g.validDistribution() && g.validPrecision() && g.validWeightMode()
+	}
+
+	if g.VertexWeighted {
+		result = result && g.validDistribution() && g.validPrecision()
 	}
 
 	if g.Connected {
 		result = result && g.validConnected()
 	}
+
+	result = result && g.validMixing() && g.validOperations() && g.validNaming() && g.validSolutions()
 	return
 }
+
+// ValidImport checks whether the request describes imported graph of permitted size.
+func (g *GraphRequest) ValidImport() bool {
+	return g.Type == Imported && g.validLimits()
+}
+
+// ValidEnumeration checks whether the base graph of batch may be enumerated,
+// the number of graphs is computed for enumerated batches so it isn't checked.
+// Mixing and operations don't apply to enumerated graphs, so they are rejected.
+func (b *BatchRequest) ValidEnumeration() bool {
+	base := &b.BaseGraph
+	return base.Nodes <= MaxEnumerationNodes && base.MixingSteps == 0 && !base.Uniform && len(base.Operations) == 0
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidSolutions(t *testing.T) {
+	request := GraphRequest{Type: Complete, Nodes: 4, Solutions: []string{"mst", "shortest-paths-from:3", "bfs-order"}}
+	assert.True(t, request.Valid())
+
+	for _, solutions := range [][]string{{"mst", "mst"}, {"shortest-paths-from"}, {"coloring"}, {"bridges:1"}} {
+		request.Solutions = solutions
+		assert.False(t, request.Valid(), solutions)
+	}
+}
+
+func TestValidMixing(t *testing.T) {
+	request := GraphRequest{Type: ExactDeg, Nodes: 10, NodeDegree: 4, Connected: true, MixingSteps: 2000}
+	assert.True(t, request.Valid())
+
+	for _, steps := range []int{-1, 2001, 2000000000} {
+		request.MixingSteps = steps
+		assert.False(t, request.Valid(), steps)
+	}
+}
+
+func TestValidNaming(t *testing.T) {
+	request := GraphRequest{Type: Complete, Nodes: 2, Naming: &VertexNaming{Scheme: ListNaming, Names: []string{"Praha", "Brno"}}}
+	assert.True(t, request.Valid())
+
+	tooMany := make([]string, configuration.Default().MaxNodes+1)
+	for k := range tooMany {
+		tooMany[k] = strconv.Itoa(k)
+	}
+	for _, names := range [][]string{
+		{"a", "a"},
+		{"a", ""},
+		{"a", strings.Repeat("b", MaxNameLength+1)},
+		{"a", "b\nc"},
+		tooMany,
+	} {
+		request.Naming.Names = names
+		assert.False(t, request.Valid(), len(names))
+	}
+}
+
+func TestValidEnumeration(t *testing.T) {
+	request := BatchRequest{Enumerate: true, BaseGraph: GraphRequest{Type: ExactDeg, Nodes: 6, NodeDegree: 2}}
+	assert.True(t, request.ValidEnumeration())
+
+	for _, base := range []GraphRequest{
+		{Type: ExactDeg, Nodes: 12, NodeDegree: 2},
+		{Type: ExactDeg, Nodes: 6, NodeDegree: 2, MixingSteps: 10},
+		{Type: ExactDeg, Nodes: 6, NodeDegree: 2, Uniform: true},
+		{Type: ExactDeg, Nodes: 6, NodeDegree: 2, Operations: []GraphOperation{{Operation: ComplementOp}}},
+	} {
+		request.BaseGraph = base
+		assert.False(t, request.ValidEnumeration(), base)
+	}
+}
+
+func TestValidDistribution(t *testing.T) {
+	valid := []GraphRequest{
+		{WeightMin: -10, WeightMax: 10, WeightDistribution: &WeightDistribution{Type: NormalDist, StdDev: 5}},
+		{WeightMin: -10, WeightMax: 0, AllowZero: true, WeightDistribution: &WeightDistribution{Type: NormalDist, Mean: 100, StdDev: 1}},
+		{WeightMin: 0, WeightMax: 10, WeightDistribution: &WeightDistribution{Type: ExponentialDist, Rate: 1}},
+		{WeightMin: 0, WeightMax: 1, FloatWeights: true},
+		{WeightMin: 0, WeightMax: 5, WeightDistribution: &WeightDistribution{Type: ZipfDist, Exponent: 2}},
+	}
+	for _, request := range valid {
+		assert.True(t, request.validDistribution(), request)
+	}
+
+	invalid := []GraphRequest{
+		{WeightMin: 0, WeightMax: 1},
+		{WeightMin: -10, WeightMax: 0, WeightDistribution: &WeightDistribution{Type: NormalDist, Mean: 100, StdDev: 1}},
+		{WeightMin: 0, WeightMax: 10, FloatWeights: true, WeightDistribution: &WeightDistribution{Type: NormalDist, Mean: -50, StdDev: 2}},
+		{WeightMin: -1, WeightMax: 0, WeightDistribution: &WeightDistribution{Type: ExponentialDist, Rate: 0.0001}},
+		{WeightMin: 0, WeightMax: 5, WeightDistribution: &WeightDistribution{Type: ZipfDist, Exponent: 50}},
+		{WeightMin: -10, WeightMax: 10, WeightDistribution: &WeightDistribution{Type: NormalDist}},
+	}
+	for _, request := range invalid {
+		assert.False(t, request.validDistribution(), request)
+	}
+}
+
+func TestNegativeCyclesMode(t *testing.T) {
+	var mode WeightMode
+	assert.ErrorIs(t, mode.UnmarshalJSON([]byte(`"no-negative-cycles"`)), ErrNegativeCyclesMode)
+	assert.NoError(t, mode.UnmarshalJSON([]byte(`"distinct"`)))
+	assert.Equal(t, DistinctWeights, mode)
+
+	request := GraphRequest{Type: Complete, Nodes: 4, Weighted: true, WeightMin: -3, WeightMax: 6, WeightMode: NoNegativeCycles}
+	assert.False(t, request.Valid())
+}
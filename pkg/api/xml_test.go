@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/xml"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func attributedTestGraph() generator.Graph {
+	path := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	weighted := generator.FloatWeightedGraph{
+		ParentGraph: path,
+		WeightsMap:  map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: 1.5, {Left: 1, Right: 2}: -2},
+		Decimals:    1,
+	}
+	attributed := generator.AttributedGraph{
+		ParentGraph:      weighted,
+		VertexWeights:    []float64{1, 2, 3},
+		VertexAttributes: []generator.Attributes{{"color": generator.StringAttr("red & blue")}, {}, {}},
+		EdgeAttributes:   map[generator.WeightedEdge]generator.Attributes{{Left: 1, Right: 2}: {"capacity": generator.IntAttr(4)}},
+	}
+	return generator.NamedGraph{ParentGraph: attributed, VertexNames: []string{"a", "b", "c"}}
+}
+
+func TestGraphML(t *testing.T) {
+	translator := &GraphMLGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := translator.Bytes()
+
+	var doc graphMLDocument
+	assert.Nil(t, xml.Unmarshal(out, &doc))
+	assert.Len(t, doc.Graph.Nodes, 3)
+	assert.Len(t, doc.Graph.Edges, 2)
+	assert.Contains(t, doc.Keys, graphMLKey{ID: "weight", For: "edge", Name: "weight", Type: "double"})
+	assert.Contains(t, doc.Keys, graphMLKey{ID: "v1", For: "node", Name: "weight", Type: "int"})
+	assert.Contains(t, doc.Graph.Nodes[0].Data, graphMLData{Key: "label", Value: "a"})
+	assert.Contains(t, doc.Graph.Nodes[0].Data, graphMLData{Key: "v0", Value: "red & blue"})
+	assert.Contains(t, doc.Graph.Edges[0].Data, graphMLData{Key: "weight", Value: "1.5"})
+	assert.Contains(t, doc.Graph.Edges[1].Data, graphMLData{Key: "e0", Value: "4"})
+}
+
+func TestGEXF(t *testing.T) {
+	translator := &GEXFGraph{}
+	assert.True(t, translator.Convert(attributedTestGraph()))
+	out := translator.Bytes()
+	assert.True(t, strings.HasPrefix(string(out), xml.Header))
+
+	var doc gexfDocument
+	assert.Nil(t, xml.Unmarshal(out, &doc))
+	assert.Len(t, doc.Graph.Attributes, 2)
+	assert.Equal(t, "b", doc.Graph.Nodes[1].Label)
+	assert.Equal(t, "-2.0", doc.Graph.Edges[1].Weight)
+	assert.Contains(t, doc.Graph.Nodes[0].Values.Values, gexfValue{For: "0", Value: "red & blue"})
+
+	translator.Convert(generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {0: true}}})
+	out = translator.Bytes()
+	assert.NotContains(t, string(out), "attvalues")
+	assert.NotContains(t, string(out), "weight")
+}
@@ -0,0 +1,147 @@
+// Package archive writes files of downloaded batches into a single archive.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrUnsupportedArchive = errors.New("requested archive format is not supported")
+
+const (
+	Zip         = "zip"
+	TarGz       = "tar.gz"
+	TarZst      = "tar.zst"
+	JSONLinesGz = "jsonl.gz"
+)
+
+var contentTypes = map[string]string{
+	Zip:         "application/zip",
+	TarGz:       "application/gzip",
+	TarZst:      "application/zstd",
+	JSONLinesGz: "application/gzip",
+}
+
+// Writer adds files to archive, the archive is complete only after Close succeeds.
+type Writer interface {
+	WriteFile(name string, content []byte) error
+	Close() error
+}
+
+// Kinds lists supported archive formats.
+func Kinds() []string {
+	return []string{Zip, TarGz, TarZst, JSONLinesGz}
+}
+
+// Supported checks whether kind names supported archive format.
+func Supported(kind string) bool {
+	_, ok := contentTypes[kind]
+	return ok
+}
+
+// ContentType returns MIME type of archive, kind is also the extension of archive.
+func ContentType(kind string) string {
+	return contentTypes[kind]
+}
+
+// New creates writer of archive of kind writing into w.
+func New(kind string, w io.Writer) (Writer, error) {
+	switch kind {
+	case Zip:
+		return &zipWriter{writer: zip.NewWriter(w)}, nil
+	case TarGz:
+		compressor := gzip.NewWriter(w)
+		return &tarWriter{writer: tar.NewWriter(compressor), compressor: compressor}, nil
+	case TarZst:
+		compressor, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &tarWriter{writer: tar.NewWriter(compressor), compressor: compressor}, nil
+	case JSONLinesGz:
+		compressor := gzip.NewWriter(w)
+		return &jsonLinesWriter{encoder: json.NewEncoder(compressor), compressor: compressor}, nil
+	}
+	return nil, ErrUnsupportedArchive
+}
+
+type zipWriter struct {
+	writer *zip.Writer
+}
+
+func (z *zipWriter) WriteFile(name string, content []byte) error {
+	f, err := z.writer.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	return err
+}
+
+func (z *zipWriter) Close() error {
+	return z.writer.Close()
+}
+
+type tarWriter struct {
+	writer     *tar.Writer
+	compressor io.WriteCloser
+}
+
+func (t *tarWriter) WriteFile(name string, content []byte) error {
+	err := t.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = t.writer.Write(content)
+	return err
+}
+
+func (t *tarWriter) Close() error {
+	if err := t.writer.Close(); err != nil {
+		return err
+	}
+	return t.compressor.Close()
+}
+
+// JSONLine is one file of jsonl archive, content which isn't valid UTF-8 is base64 encoded.
+type JSONLine struct {
+	Name     string `json:"name"`
+	Encoding string `json:"encoding,omitempty"`
+	Content  string `json:"content"`
+}
+
+type jsonLinesWriter struct {
+	encoder    *json.Encoder
+	compressor io.WriteCloser
+}
+
+func (j *jsonLinesWriter) WriteFile(name string, content []byte) error {
+	line := JSONLine{Name: name, Content: string(content)}
+	if !utf8.Valid(content) {
+		line.Encoding = "base64"
+		line.Content = base64.StdEncoding.EncodeToString(content)
+	}
+	return j.encoder.Encode(line)
+}
+
+func (j *jsonLinesWriter) Close() error {
+	return j.compressor.Close()
+}
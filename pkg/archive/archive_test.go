@@ -0,0 +1,110 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+var testFiles = []struct {
+	name    string
+	content []byte
+}{
+	{"rngr-1.txt", []byte("0 1\n1 0\n")},
+	{"rngr-2.csv", []byte{0x50, 0x4b, 0xff, 0x00}},
+	{"manifest.json", []byte(`{"graphs": []}`)},
+}
+
+func writeArchive(t *testing.T, kind string) []byte {
+	buffer := bytes.Buffer{}
+	writer, err := New(kind, &buffer)
+	assert.NoError(t, err)
+	for _, f := range testFiles {
+		assert.NoError(t, writer.WriteFile(f.name, f.content))
+	}
+	assert.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func readTar(t *testing.T, r io.Reader) map[string][]byte {
+	result := make(map[string][]byte)
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return result
+		}
+		assert.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		result[header.Name] = content
+	}
+}
+
+func assertFiles(t *testing.T, kind string, files map[string][]byte) {
+	assert.Len(t, files, len(testFiles), kind)
+	for _, f := range testFiles {
+		assert.Equal(t, f.content, files[f.name], kind)
+	}
+}
+
+func TestZip(t *testing.T) {
+	data := writeArchive(t, Zip)
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		files[f.Name], _ = io.ReadAll(r)
+	}
+	assertFiles(t, Zip, files)
+}
+
+func TestTar(t *testing.T) {
+	gz, err := gzip.NewReader(bytes.NewReader(writeArchive(t, TarGz)))
+	assert.NoError(t, err)
+	assertFiles(t, TarGz, readTar(t, gz))
+
+	zst, err := zstd.NewReader(bytes.NewReader(writeArchive(t, TarZst)))
+	assert.NoError(t, err)
+	defer zst.Close()
+	assertFiles(t, TarZst, readTar(t, zst))
+}
+
+func TestJSONLines(t *testing.T) {
+	gz, err := gzip.NewReader(bytes.NewReader(writeArchive(t, JSONLinesGz)))
+	assert.NoError(t, err)
+	files := make(map[string][]byte)
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var line JSONLine
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		if line.Encoding == "base64" {
+			files[line.Name], err = base64.StdEncoding.DecodeString(line.Content)
+			assert.NoError(t, err)
+		} else {
+			assert.Empty(t, line.Encoding)
+			files[line.Name] = []byte(line.Content)
+		}
+	}
+	assertFiles(t, JSONLinesGz, files)
+}
+
+func TestUnsupported(t *testing.T) {
+	_, err := New("rar", io.Discard)
+	assert.ErrorIs(t, err, ErrUnsupportedArchive)
+	assert.False(t, Supported("rar"))
+	for _, kind := range Kinds() {
+		assert.True(t, Supported(kind))
+		assert.NotEmpty(t, ContentType(kind))
+	}
+}
@@ -0,0 +1,198 @@
+package algorithms
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math/bits"
+)
+
+// maxEnumeratedNodes is the hard limit given by the bitmask representation of adjacency.
+const maxEnumeratedNodes = 32
+
+// EnumerationConstraints limits which graphs are returned by EnumerateGraphs.
+// Edges set to negative value means that the number of edges is not restricted.
+type EnumerationConstraints struct {
+	MinDegree, MaxDegree int
+	Edges                int
+	Connected            bool
+}
+
+type enumerator struct {
+	nodes       int
+	constraints EnumerationConstraints
+	limit       int
+	adj         []uint32
+	edges       int
+	result      []generator.SimpleGraph
+}
+
+// EnumerateGraphs returns every non-isomorphic graph on passed number of nodes satisfying the
+// constraints, each exactly once. It implements orderly generation of Read and Faradzev:
+// graph is canonical when its upper triangle of adjacency matrix read column by column is
+// lexicographically maximal among all labellings. Removing the last vertex of canonical graph
+// yields canonical graph again, so graphs are built vertex by vertex and every extension which
+// isn't canonical is rejected without the need to remember already generated graphs.
+// The enumeration stops with generator.ErrTooManyGraphs as soon as more than limit graphs are found.
+func EnumerateGraphs(nodes int, constraints EnumerationConstraints, limit int) ([]generator.SimpleGraph, error) {
+	if nodes <= 0 || nodes > maxEnumeratedNodes || constraints.MinDegree > constraints.MaxDegree {
+		return nil, generator.ErrInvalidProperties
+	}
+	e := enumerator{
+		nodes:       nodes,
+		constraints: constraints,
+		limit:       limit,
+		adj:         make([]uint32, nodes),
+		result:      make([]generator.SimpleGraph, 0),
+	}
+	if !e.extend(0) {
+		return nil, generator.ErrTooManyGraphs
+	}
+	return e.result, nil
+}
+
+// extend tries all canonical extensions of the graph on first k vertices,
+// returns false when the limit was exceeded.
+func (e *enumerator) extend(k int) bool {
+	if k == e.nodes {
+		if !e.accepted() {
+			return true
+		}
+		if len(e.result) >= e.limit {
+			return false
+		}
+		e.result = append(e.result, e.export())
+		return true
+	}
+	for mask := uint32(0); mask < 1<<k; mask++ {
+		e.addVertex(k, mask)
+		if e.feasible(k+1) && e.canonical(k+1) && !e.extend(k+1) {
+			e.removeVertex(k, mask)
+			return false
+		}
+		e.removeVertex(k, mask)
+	}
+	return true
+}
+
+func (e *enumerator) addVertex(k int, mask uint32) {
+	e.adj[k] = mask
+	for i := 0; i < k; i++ {
+		if mask&(1<<i) != 0 {
+			e.adj[i] |= 1 << k
+		}
+	}
+	e.edges += bits.OnesCount32(mask)
+}
+
+func (e *enumerator) removeVertex(k int, mask uint32) {
+	for i := 0; i < k; i++ {
+		e.adj[i] &^= 1 << k
+	}
+	e.adj[k] = 0
+	e.edges -= bits.OnesCount32(mask)
+}
+
+// feasible checks whether graph on first k vertices can still be extended into a graph
+// satisfying the constraints, every remaining vertex can add at most one edge to each vertex.
+func (e *enumerator) feasible(k int) bool {
+	remaining := e.nodes - k
+	for i := 0; i < k; i++ {
+		deg := bits.OnesCount32(e.adj[i])
+		if deg > e.constraints.MaxDegree || deg+remaining < e.constraints.MinDegree {
+			return false
+		}
+	}
+	if e.constraints.Edges < 0 {
+		return true
+	}
+	reachable := e.edges
+	for j := k; j < e.nodes; j++ {
+		reachable += j
+	}
+	return e.edges <= e.constraints.Edges && reachable >= e.constraints.Edges
+}
+
+func (e *enumerator) accepted() bool {
+	if e.constraints.Edges >= 0 && e.edges != e.constraints.Edges {
+		return false
+	}
+	return !e.constraints.Connected || e.connected()
+}
+
+func (e *enumerator) connected() bool {
+	found := uint32(1)
+	frontier := uint32(1)
+	for frontier != 0 {
+		next := uint32(0)
+		for frontier != 0 {
+			v := bits.TrailingZeros32(frontier)
+			frontier &^= 1 << v
+			next |= e.adj[v]
+		}
+		frontier = next &^ found
+		found |= next
+	}
+	return bits.OnesCount32(found) == e.nodes
+}
+
+// canonical checks that no relabelling of the graph on first k vertices has larger code.
+func (e *enumerator) canonical(k int) bool {
+	perm := make([]int, k)
+	return !e.larger(perm, 0, 0, k)
+}
+
+// larger searches for labelling with larger code than identity. The labelling is built
+// position by position, and only prefixes producing the same code as identity are expanded
+// further. Unused vertices with the same neighbourhood are interchangeable, so only one of them
+// is tried on every position.
+func (e *enumerator) larger(perm []int, used uint32, depth, k int) bool {
+	if depth == k {
+		return false
+	}
+	tried := uint32(0)
+	for v := 0; v < k; v++ {
+		if (used|tried)&(1<<v) != 0 {
+			continue
+		}
+		tried |= e.twins(v, used, k)
+		cmp := 0
+		for i := 0; i < depth && cmp == 0; i++ {
+			cmp = int(e.adj[perm[i]]>>v&1) - int(e.adj[i]>>depth&1)
+		}
+		if cmp > 0 {
+			return true
+		}
+		if cmp < 0 {
+			continue
+		}
+		perm[depth] = v
+		if e.larger(perm, used|1<<v, depth+1, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// twins returns mask of unused vertices which can be swapped with v without change of the graph.
+func (e *enumerator) twins(v int, used uint32, k int) uint32 {
+	result := uint32(0)
+	for u := 0; u < k; u++ {
+		if used&(1<<u) != 0 {
+			continue
+		}
+		if e.adj[u]&^(1<<v) == e.adj[v]&^(1<<u) {
+			result |= 1 << u
+		}
+	}
+	return result
+}
+
+func (e *enumerator) export() generator.SimpleGraph {
+	edges := make([]map[int]bool, e.nodes)
+	for i := range edges {
+		edges[i] = make(map[int]bool)
+		for mask := e.adj[i]; mask != 0; mask &= mask - 1 {
+			edges[i][bits.TrailingZeros32(mask)] = true
+		}
+	}
+	return generator.SimpleGraph{Size: e.nodes, EdgesMap: edges}
+}
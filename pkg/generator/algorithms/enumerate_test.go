@@ -0,0 +1,78 @@
+package algorithms
+
+import (
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func unrestricted(nodes int) EnumerationConstraints {
+	return EnumerationConstraints{MinDegree: 0, MaxDegree: nodes - 1, Edges: -1}
+}
+
+func TestEnumerateAllGraphs(t *testing.T) {
+	t.Parallel()
+	// OEIS A000088 and A001349
+	all := []int{1, 2, 4, 11, 34, 156, 1044}
+	connected := []int{1, 1, 2, 6, 21, 112, 853}
+	for k := range all {
+		nodes := k + 1
+		t.Run(fmt.Sprintf("n=%d", nodes), func(t *testing.T) {
+			res, err := EnumerateGraphs(nodes, unrestricted(nodes), 100000)
+			assert.Nil(t, err)
+			assert.Len(t, res, all[k])
+			for _, v := range res {
+				CheckGraph(t, v.Edges())
+			}
+
+			constraints := unrestricted(nodes)
+			constraints.Connected = true
+			res, err = EnumerateGraphs(nodes, constraints, 100000)
+			assert.Nil(t, err)
+			assert.Len(t, res, connected[k])
+			for _, v := range res {
+				CheckConnectivity(t, v.Edges())
+			}
+		})
+	}
+}
+
+func TestEnumerateCubic(t *testing.T) {
+	t.Parallel()
+	// OEIS A005638 and A002851
+	nodes := []int{4, 6, 8, 10}
+	all := []int{1, 2, 6, 21}
+	connected := []int{1, 2, 5, 19}
+	for k, n := range nodes {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			constraints := EnumerationConstraints{MinDegree: 3, MaxDegree: 3, Edges: -1}
+			res, err := EnumerateGraphs(n, constraints, 1000)
+			assert.Nil(t, err)
+			assert.Len(t, res, all[k])
+			for _, v := range res {
+				checkGraphDegrees(t, v, n, 3)
+			}
+
+			constraints.Connected = true
+			res, err = EnumerateGraphs(n, constraints, 1000)
+			assert.Nil(t, err)
+			assert.Len(t, res, connected[k])
+		})
+	}
+}
+
+func TestEnumerateEdges(t *testing.T) {
+	// trees on seven nodes, OEIS A000055
+	constraints := EnumerationConstraints{MinDegree: 1, MaxDegree: 6, Edges: 6, Connected: true}
+	res, err := EnumerateGraphs(7, constraints, 1000)
+	assert.Nil(t, err)
+	assert.Len(t, res, 11)
+}
+
+func TestEnumerateLimit(t *testing.T) {
+	_, err := EnumerateGraphs(6, unrestricted(6), 100)
+	assert.ErrorIs(t, err, generator.ErrTooManyGraphs)
+	_, err = EnumerateGraphs(0, unrestricted(0), 100)
+	assert.Error(t, err)
+}
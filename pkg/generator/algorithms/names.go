@@ -0,0 +1,58 @@
+package algorithms
+
+import (
+	_ "embed"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	mrand "math/rand"
+	"strconv"
+	"strings"
+)
+
+//go:embed data/cities.txt
+var citiesList string
+
+var cities = strings.Fields(citiesList)
+
+// NumericNames names the nodes by consecutive numbers starting at start.
+func NumericNames(nodes, start int) []string {
+	result := make([]string, nodes)
+	for k := range result {
+		result[k] = strconv.Itoa(k + start)
+	}
+	return result
+}
+
+// AlphabeticNames names the nodes A, B, ..., Z, AA, AB, ... like spreadsheet columns.
+func AlphabeticNames(nodes int) []string {
+	result := make([]string, nodes)
+	for k := range result {
+		name := make([]byte, 0, 2)
+		for n := k + 1; n > 0; n = (n - 1) / 26 {
+			name = append([]byte{byte('A' + (n-1)%26)}, name...)
+		}
+		result[k] = string(name)
+	}
+	return result
+}
+
+// CityNames names the nodes by randomly chosen city names from the embedded list,
+// when there are more nodes than cities the names are reused with numeric suffix.
+func CityNames(nodes int, rand *mrand.Rand) ([]string, error) {
+	if rand == nil {
+		return nil, generator.ErrMissingRand
+	}
+	result := make([]string, 0, nodes)
+	for round := 1; len(result) < nodes; round++ {
+		for _, k := range rand.Perm(len(cities)) {
+			if len(result) == nodes {
+				break
+			}
+			name := cities[k]
+			if round > 1 {
+				name += " " + strconv.Itoa(round)
+			}
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
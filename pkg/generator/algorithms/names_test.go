@@ -0,0 +1,37 @@
+package algorithms
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNumericNames(t *testing.T) {
+	assert.Equal(t, []string{"0", "1", "2"}, NumericNames(3, 0))
+	assert.Equal(t, []string{"1", "2", "3"}, NumericNames(3, 1))
+}
+
+func TestAlphabeticNames(t *testing.T) {
+	names := AlphabeticNames(703)
+	assert.Equal(t, "A", names[0])
+	assert.Equal(t, "Z", names[25])
+	assert.Equal(t, "AA", names[26])
+	assert.Equal(t, "AZ", names[51])
+	assert.Equal(t, "ZZ", names[701])
+	assert.Equal(t, "AAA", names[702])
+}
+
+func TestCityNames(t *testing.T) {
+	nodes := 2*len(cities) + 5
+	names, err := CityNames(nodes, getRand(3))
+	assert.Nil(t, err)
+	assert.Len(t, names, nodes)
+	used := make(map[string]bool)
+	for _, v := range names {
+		assert.False(t, used[v])
+		used[v] = true
+	}
+	same, _ := CityNames(nodes, getRand(3))
+	assert.Equal(t, names, same)
+	_, err = CityNames(3, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package algorithms
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	mrand "math/rand"
+	"sort"
+)
+
+func newEdgesMap(nodes int) []map[int]bool {
+	edges := make([]map[int]bool, nodes)
+	for k := range edges {
+		edges[k] = make(map[int]bool)
+	}
+	return edges
+}
+
+func addEdge(edges []map[int]bool, u, v int) {
+	edges[u][v] = true
+	edges[v][u] = true
+}
+
+// listEdges returns all edges of the graph ordered by left and then by right node.
+func listEdges(graph []map[int]bool) []generator.WeightedEdge {
+	result := make([]generator.WeightedEdge, 0)
+	for k := range graph {
+		for j, ok := range graph[k] {
+			if ok && k < j {
+				result = append(result, generator.WeightedEdge{Left: k, Right: j})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Left != result[j].Left {
+			return result[i].Left < result[j].Left
+		}
+		return result[i].Right < result[j].Right
+	})
+	return result
+}
+
+// Complement returns graph with edges exactly between the nodes not adjacent in passed graph.
+func Complement(graph generator.Graph) generator.SimpleGraph {
+	edges := invertGraph(graph.Edges())
+	return generator.SimpleGraph{Size: len(edges), EdgesMap: edges}
+}
+
+// LineGraph returns graph whose nodes are the edges of passed graph ordered as by listEdges,
+// two nodes are adjacent if the edges share an endpoint.
+func LineGraph(graph generator.Graph) generator.SimpleGraph {
+	list := listEdges(graph.Edges())
+	incident := make([][]int, len(graph.Edges()))
+	for k, v := range list {
+		incident[v.Left] = append(incident[v.Left], k)
+		incident[v.Right] = append(incident[v.Right], k)
+	}
+	edges := newEdgesMap(len(list))
+	for _, inc := range incident {
+		for i := range inc {
+			for j := i + 1; j < len(inc); j++ {
+				addEdge(edges, inc[i], inc[j])
+			}
+		}
+	}
+	return generator.SimpleGraph{Size: len(list), EdgesMap: edges}
+}
+
+// product builds graph on pairs of nodes, pair (u, v) has index u*|second|+v.
+// Distinct pairs are adjacent when the adjacent function returns true for them.
+func product(first, second generator.Graph, adjacent func(eqL, adjL, eqR, adjR bool) bool) generator.SimpleGraph {
+	left, right := first.Edges(), second.Edges()
+	nl, nr := len(left), len(right)
+	edges := newEdgesMap(nl * nr)
+	for u := 0; u < nl; u++ {
+		for v := 0; v < nr; v++ {
+			for u2 := u; u2 < nl; u2++ {
+				if u2 != u && !left[u][u2] {
+					continue
+				}
+				for v2 := 0; v2 < nr; v2++ {
+					if u2 == u && v2 <= v {
+						continue
+					}
+					if adjacent(u == u2, left[u][u2], v == v2, right[v][v2]) {
+						addEdge(edges, u*nr+v, u2*nr+v2)
+					}
+				}
+			}
+		}
+	}
+	return generator.SimpleGraph{Size: nl * nr, EdgesMap: edges}
+}
+
+// CartesianProduct returns the Cartesian product, (u,v) and (u',v') are adjacent
+// if one coordinate is equal and the other is adjacent.
+func CartesianProduct(first, second generator.Graph) generator.SimpleGraph {
+	return product(first, second, func(eqL, adjL, eqR, adjR bool) bool {
+		return (eqL && adjR) || (adjL && eqR)
+	})
+}
+
+// TensorProduct returns the tensor product, (u,v) and (u',v') are adjacent
+// if both coordinates are adjacent.
+func TensorProduct(first, second generator.Graph) generator.SimpleGraph {
+	return product(first, second, func(eqL, adjL, eqR, adjR bool) bool {
+		return adjL && adjR
+	})
+}
+
+// StrongProduct returns the strong product, which is the union of Cartesian and tensor product.
+func StrongProduct(first, second generator.Graph) generator.SimpleGraph {
+	return product(first, second, func(eqL, adjL, eqR, adjR bool) bool {
+		return (eqL || adjL) && (eqR || adjR)
+	})
+}
+
+// DisjointUnion returns union of the graphs, nodes of second graph are shifted after the first ones.
+func DisjointUnion(first, second generator.Graph) generator.SimpleGraph {
+	left, right := first.Edges(), second.Edges()
+	edges := newEdgesMap(len(left) + len(right))
+	for k := range left {
+		for j, ok := range left[k] {
+			if ok {
+				edges[k][j] = true
+			}
+		}
+	}
+	for k := range right {
+		for j, ok := range right[k] {
+			if ok {
+				edges[len(left)+k][len(left)+j] = true
+			}
+		}
+	}
+	return generator.SimpleGraph{Size: len(edges), EdgesMap: edges}
+}
+
+// Join returns disjoint union of the graphs with every node of first graph
+// connected to every node of the second one.
+func Join(first, second generator.Graph) generator.SimpleGraph {
+	result := DisjointUnion(first, second)
+	nl := len(first.Edges())
+	for k := 0; k < nl; k++ {
+		for j := nl; j < result.Size; j++ {
+			addEdge(result.EdgesMap, k, j)
+		}
+	}
+	return result
+}
+
+// Subdivision replaces every edge with path of length two, the new nodes are appended
+// after the original ones in order given by listEdges.
+func Subdivision(graph generator.Graph) generator.SimpleGraph {
+	list := listEdges(graph.Edges())
+	nodes := len(graph.Edges())
+	edges := newEdgesMap(nodes + len(list))
+	for k, v := range list {
+		addEdge(edges, v.Left, nodes+k)
+		addEdge(edges, v.Right, nodes+k)
+	}
+	return generator.SimpleGraph{Size: len(edges), EdgesMap: edges}
+}
+
+// RandomPermutation relabels nodes of the graph by uniformly chosen permutation.
+func RandomPermutation(graph generator.Graph, rand *mrand.Rand) (generator.SimpleGraph, error) {
+	if rand == nil {
+		return generator.SimpleGraph{}, generator.ErrMissingRand
+	}
+	nodes := len(graph.Edges())
+	perm := rand.Perm(nodes)
+	edges := newEdgesMap(nodes)
+	for k := range graph.Edges() {
+		for j, ok := range graph.Edges()[k] {
+			if ok {
+				edges[perm[k]][perm[j]] = true
+			}
+		}
+	}
+	return generator.SimpleGraph{Size: nodes, EdgesMap: edges}, nil
+}
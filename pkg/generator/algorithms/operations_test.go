@@ -0,0 +1,99 @@
+package algorithms
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func pathGraph(nodes int) generator.SimpleGraph {
+	edges := newEdgesMap(nodes)
+	for k := 1; k < nodes; k++ {
+		addEdge(edges, k-1, k)
+	}
+	return generator.SimpleGraph{Size: nodes, EdgesMap: edges}
+}
+
+func sortedDegrees(g generator.Graph) []int {
+	res := extractNodeDegFromGraph(g.Edges())
+	sort.Ints(res)
+	return res
+}
+
+func TestComplement(t *testing.T) {
+	res := Complement(testingGraph)
+	assert.Equal(t, 5, res.Size)
+	checkGraphDegrees(t, res, 5, 0)
+	res = Complement(pathGraph(4))
+	CheckGraph(t, res.Edges())
+	assert.Equal(t, []int{1, 1, 2, 2}, sortedDegrees(res))
+}
+
+func TestLineGraph(t *testing.T) {
+	complete, _ := GenerateRandomComplete(4)
+	res := LineGraph(complete)
+	CheckGraph(t, res.Edges())
+	checkGraphDegrees(t, res, 6, 4)
+
+	res = LineGraph(pathGraph(5))
+	assert.Equal(t, 4, res.Size)
+	assert.Equal(t, []int{1, 1, 2, 2}, sortedDegrees(res))
+}
+
+func TestProducts(t *testing.T) {
+	edge := pathGraph(2)
+	res := CartesianProduct(edge, edge)
+	CheckGraph(t, res.Edges())
+	checkGraphDegrees(t, res, 4, 2)
+	CheckConnectivity(t, res.Edges())
+
+	res = TensorProduct(edge, edge)
+	CheckGraph(t, res.Edges())
+	checkGraphDegrees(t, res, 4, 1)
+
+	res = StrongProduct(edge, edge)
+	CheckGraph(t, res.Edges())
+	checkGraphDegrees(t, res, 4, 3)
+
+	res = CartesianProduct(pathGraph(3), testingGraph)
+	CheckGraph(t, res.Edges())
+	assert.Equal(t, 15, res.Size)
+	assert.Equal(t, 3*10+5*2, len(listEdges(res.Edges())))
+}
+
+func TestUnionAndJoin(t *testing.T) {
+	res := DisjointUnion(pathGraph(3), testingGraph)
+	CheckGraph(t, res.Edges())
+	assert.Equal(t, 8, res.Size)
+	assert.Equal(t, 2+10, len(listEdges(res.Edges())))
+	assert.Len(t, extractComponents(res.Edges()), 2)
+
+	res = Join(pathGraph(3), testingGraph)
+	CheckGraph(t, res.Edges())
+	assert.Equal(t, 2+10+15, len(listEdges(res.Edges())))
+	CheckConnectivity(t, res.Edges())
+}
+
+func TestSubdivision(t *testing.T) {
+	res := Subdivision(testingGraph)
+	CheckGraph(t, res.Edges())
+	assert.Equal(t, 15, res.Size)
+	for k := 5; k < 15; k++ {
+		assert.Len(t, res.Edges()[k], 2)
+	}
+	for k := 0; k < 5; k++ {
+		assert.Len(t, res.Edges()[k], 4)
+	}
+}
+
+func TestRandomPermutation(t *testing.T) {
+	graph := pathGraph(20)
+	res, err := RandomPermutation(graph, getRand(31))
+	assert.Nil(t, err)
+	CheckGraph(t, res.Edges())
+	CheckConnectivity(t, res.Edges())
+	assert.Equal(t, sortedDegrees(graph), sortedDegrees(res))
+	_, err = RandomPermutation(graph, nil)
+	assert.Error(t, err)
+}
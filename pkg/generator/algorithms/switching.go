@@ -0,0 +1,114 @@
+package algorithms
+
+import (
+	"github.com/gammazero/deque"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	mrand "math/rand"
+)
+
+// DefaultMixingFactor is multiplied by the number of edges to obtain the number of
+// switching steps used when near-uniform sample is requested without explicit steps.
+const DefaultMixingFactor = 10
+
+// DefaultMixingSteps returns number of steps of the switching chain
+// used for the graph with passed number of edges.
+func DefaultMixingSteps(edges int) int {
+	return DefaultMixingFactor * edges
+}
+
+// MixEdgeSwitch runs degree-preserving double-edge-switch Markov chain on the passed graph.
+// In each step two distinct edges {a,b} and {c,d} are chosen uniformly together with one of
+// the two possible orientations and replaced by {a,d} and {c,b}. Switches that would create
+// a loop or a multi-edge are rejected and the step is counted anyway, which keeps the chain
+// symmetric, so its stationary distribution is uniform over all simple graphs with the degree
+// sequence of the input. When connected is set, switches disconnecting the graph are rejected
+// as well and the chain samples uniformly from the connected graphs with that degree sequence.
+// The input graph is left untouched.
+func MixEdgeSwitch(graph generator.Graph, steps int, connected bool, rand *mrand.Rand) (generator.SimpleGraph, error) {
+	if rand == nil {
+		return generator.SimpleGraph{}, generator.ErrMissingRand
+	}
+	if steps < 0 {
+		return generator.SimpleGraph{}, generator.ErrInvalidProperties
+	}
+	nodes := len(graph.Edges())
+	edges := make([]map[int]bool, nodes)
+	list := make([]generator.WeightedEdge, 0)
+	for k, v := range graph.Edges() {
+		edges[k] = make(map[int]bool, len(v))
+		for j, ok := range v {
+			if !ok {
+				continue
+			}
+			edges[k][j] = true
+			if k < j {
+				list = append(list, generator.WeightedEdge{Left: k, Right: j})
+			}
+		}
+	}
+	if len(list) < 2 {
+		return generator.SimpleGraph{Size: nodes, EdgesMap: edges}, nil
+	}
+
+	for step := 0; step < steps; step++ {
+		first, second := rand.Intn(len(list)), rand.Intn(len(list)-1)
+		if second >= first {
+			second++
+		}
+		a, b := list[first].Left, list[first].Right
+		c, d := list[second].Left, list[second].Right
+		if rand.Intn(2) == 1 {
+			c, d = d, c
+		}
+		if a == d || c == b || edges[a][d] || edges[c][b] {
+			continue
+		}
+		switchEdge(edges, a, b, c, d)
+		if connected && !reachableAll(edges, a, b, c, d) {
+			switchEdge(edges, a, d, c, b)
+			continue
+		}
+		list[first] = generator.CreateEdge(a, d)
+		list[second] = generator.CreateEdge(c, b)
+	}
+	return generator.SimpleGraph{Size: nodes, EdgesMap: edges}, nil
+}
+
+// switchEdge replaces edges {a,b} and {c,d} with {a,d} and {c,b}.
+func switchEdge(edges []map[int]bool, a, b, c, d int) {
+	delete(edges[a], b)
+	delete(edges[b], a)
+	delete(edges[c], d)
+	delete(edges[d], c)
+	edges[a][d] = true
+	edges[d][a] = true
+	edges[c][b] = true
+	edges[b][c] = true
+}
+
+// reachableAll checks whether all targets are reachable from the start node.
+// After one switch of connected graph only the endpoints of removed edges can be
+// split apart, so it is sufficient to check those instead of the whole graph.
+func reachableAll(edges []map[int]bool, start int, targets ...int) bool {
+	remaining := make(map[int]bool)
+	for _, v := range targets {
+		if v != start {
+			remaining[v] = true
+		}
+	}
+	found := map[int]bool{start: true}
+	queue := deque.New[int]()
+	queue.PushBack(start)
+	for queue.Len() != 0 && len(remaining) != 0 {
+		elem := queue.PopFront()
+		for k, ok := range edges[elem] {
+			if !ok || found[k] {
+				continue
+			}
+			found[k] = true
+			delete(remaining, k)
+			queue.PushBack(k)
+		}
+	}
+	return len(remaining) == 0
+}
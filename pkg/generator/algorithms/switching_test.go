@@ -0,0 +1,70 @@
+package algorithms
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestMixEdgeSwitchPreservesDegrees(t *testing.T) {
+	t.Parallel()
+	seeds := []int64{1, 31, 2353, 122535}
+	for _, seed := range seeds {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(seed))
+			graph, err := GenerateRandomBetween(40, 2, 6, true, rnd)
+			assert.Nil(t, err)
+			expected := extractNodeDegFromGraph(graph.Edges())
+
+			mixed, err := MixEdgeSwitch(graph, 2000, true, rnd)
+			assert.Nil(t, err)
+			CheckGraph(t, mixed.Edges())
+			CheckConnectivity(t, mixed.Edges())
+			assert.Equal(t, expected, extractNodeDegFromGraph(mixed.Edges()))
+			assert.Equal(t, expected, extractNodeDegFromGraph(graph.Edges()))
+		})
+	}
+}
+
+func TestMixEdgeSwitchInvalid(t *testing.T) {
+	_, err := MixEdgeSwitch(testingGraph, 10, false, nil)
+	assert.Error(t, err)
+	_, err = MixEdgeSwitch(testingGraph, -1, false, getRand(1))
+	assert.Error(t, err)
+}
+
+func TestMixEdgeSwitchUniformMatchings(t *testing.T) {
+	// There are exactly three perfect matchings on four nodes,
+	// every one of them should be sampled with the same probability.
+	rnd := rand.New(rand.NewSource(97))
+	graph, err := GenerateStegerWormald(4, 1, false, rnd)
+	assert.Nil(t, err)
+
+	samples := 3000
+	counts := make(map[int]int)
+	for k := 0; k < samples; k++ {
+		mixed, err := MixEdgeSwitch(graph, 20, false, rnd)
+		assert.Nil(t, err)
+		for j := range mixed.Edges()[0] {
+			counts[j]++
+		}
+	}
+	assert.Len(t, counts, 3)
+	for _, v := range counts {
+		assert.InDelta(t, samples/3, v, float64(samples)/15)
+	}
+}
+
+func TestMixEdgeSwitchKeepsConnected(t *testing.T) {
+	// 2-regular graphs on six nodes are either a hexagon or two triangles,
+	// connected chain must never reach the latter.
+	rnd := rand.New(rand.NewSource(5))
+	graph, err := GenerateStegerWormald(6, 2, true, rnd)
+	assert.Nil(t, err)
+	for k := 0; k < 200; k++ {
+		graph, err = MixEdgeSwitch(graph, 5, true, rnd)
+		assert.Nil(t, err)
+		CheckConnectivity(t, graph.Edges())
+	}
+}
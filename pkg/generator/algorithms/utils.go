@@ -3,6 +3,7 @@ package algorithms
 import (
 	"github.com/gammazero/deque"
 	mrand "math/rand"
+	"sort"
 )
 
 // getNthElem returns nth found element from map.
@@ -74,6 +75,21 @@ func extractComponents(graph []map[int]bool) []map[int]map[int]bool {
 	return components
 }
 
+// Components returns nodes of every component of connectivity in the graph,
+// nodes of each component are sorted.
+func Components(graph []map[int]bool) [][]int {
+	components := extractComponents(graph)
+	result := make([][]int, len(components))
+	for k, component := range components {
+		result[k] = make([]int, 0, len(component))
+		for node := range component {
+			result[k] = append(result[k], node)
+		}
+		sort.Ints(result[k])
+	}
+	return result
+}
+
 // removeIndex removes element at passed index from the slice and shakes slice
 // to be of correct size.
 func removeIndex[T any](index int, slice []T) []T {
@@ -43,3 +43,10 @@ func CheckGraph(t *testing.T, data []map[int]bool) {
 		}
 	}
 }
+
+func TestComponents(t *testing.T) {
+	graph := []map[int]bool{{3: true}, {2: true}, {1: true}, {0: true}, {}}
+	components := Components(graph)
+	assert.ElementsMatch(t, [][]int{{0, 3}, {1, 2}, {4}}, components)
+	assert.Empty(t, Components([]map[int]bool{}))
+}
@@ -0,0 +1,150 @@
+package algorithms
+
+import (
+	"container/heap"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math"
+	mrand "math/rand"
+	"sort"
+)
+
+type distItem struct {
+	node int
+	dist int
+}
+
+type distHeap []distItem
+
+func (h distHeap) Len() int {
+	return len(h)
+}
+
+func (h distHeap) Less(i, j int) bool {
+	if h[i].dist != h[j].dist {
+		return h[i].dist < h[j].dist
+	}
+	return h[i].node < h[j].node
+}
+
+func (h distHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *distHeap) Push(x any) {
+	*h = append(*h, x.(distItem))
+}
+
+func (h *distHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// sortedNeighbours returns adjacency lists of the graph in ascending order.
+func sortedNeighbours(edges []map[int]bool) [][]int {
+	result := make([][]int, len(edges))
+	for k := range edges {
+		for j, ok := range edges[k] {
+			if ok {
+				result[k] = append(result[k], j)
+			}
+		}
+		sort.Ints(result[k])
+	}
+	return result
+}
+
+// findShortestPathTie runs Dijkstra's algorithm from source and returns all edges
+// finishing shortest path to the first found node reachable by more shortest paths.
+// Empty slice means that all shortest paths from source are unique. Weights must be positive.
+func findShortestPathTie(edges [][]int, weights map[generator.WeightedEdge]int, source int) []generator.WeightedEdge {
+	dist := make([]int, len(edges))
+	for k := range dist {
+		dist[k] = math.MaxInt
+	}
+	done := make([]bool, len(edges))
+	dist[source] = 0
+	queue := &distHeap{{node: source, dist: 0}}
+	for queue.Len() != 0 {
+		item := heap.Pop(queue).(distItem)
+		if done[item.node] {
+			continue
+		}
+		done[item.node] = true
+		ties := make([]generator.WeightedEdge, 0)
+		for _, k := range edges[item.node] {
+			if !done[k] {
+				continue
+			}
+			if dist[k]+weights[generator.CreateEdge(k, item.node)] == item.dist {
+				ties = append(ties, generator.CreateEdge(k, item.node))
+			}
+		}
+		if len(ties) > 1 {
+			return ties
+		}
+		for _, k := range edges[item.node] {
+			if done[k] {
+				continue
+			}
+			newDist := item.dist + weights[generator.CreateEdge(k, item.node)]
+			if newDist < dist[k] {
+				dist[k] = newDist
+				heap.Push(queue, distItem{node: k, dist: newDist})
+			}
+		}
+	}
+	return []generator.WeightedEdge{}
+}
+
+// EnsureUniqueShortestPaths redraws weights of edges on tied shortest paths until every
+// pair of nodes is connected by single shortest path, which is verified by Dijkstra's
+// algorithm from every node. All weights, including the redrawn ones, must be positive.
+func EnsureUniqueShortestPaths(edges []map[int]bool, weights map[generator.WeightedEdge]int, redraw WeightSampler, rand *mrand.Rand) error {
+	if rand == nil {
+		return generator.ErrMissingRand
+	}
+	budget := maxDrawAttempts + 10*len(weights)
+	neighbours := sortedNeighbours(edges)
+	for changed := true; changed; {
+		changed = false
+		for source := range neighbours {
+			ties := findShortestPathTie(neighbours, weights, source)
+			for len(ties) != 0 {
+				if budget == 0 {
+					return generator.ErrWeightsNotFound
+				}
+				budget--
+				edge := ties[rand.Intn(len(ties))]
+				value := redraw()
+				if value <= 0 {
+					return generator.ErrInvalidWeight
+				}
+				weights[edge] = value
+				changed = true
+				ties = findShortestPathTie(neighbours, weights, source)
+			}
+		}
+	}
+	return nil
+}
+
+// ScaleWeights converts weights with passed number of decimal places to integers, so they
+// can be compared exactly. UnscaleWeights converts them back.
+func ScaleWeights(weights map[generator.WeightedEdge]float64, decimals int) map[generator.WeightedEdge]int {
+	scale := math.Pow10(decimals)
+	result := make(map[generator.WeightedEdge]int, len(weights))
+	for k, v := range weights {
+		result[k] = int(math.Round(v * scale))
+	}
+	return result
+}
+
+// UnscaleWeights stores integer weights obtained by ScaleWeights back to the float weights.
+func UnscaleWeights(scaled map[generator.WeightedEdge]int, weights map[generator.WeightedEdge]float64, decimals int) {
+	scale := math.Pow10(decimals)
+	for k, v := range scaled {
+		weights[k] = float64(v) / scale
+	}
+}
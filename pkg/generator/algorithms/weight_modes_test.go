@@ -0,0 +1,56 @@
+package algorithms
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDistinctWeights(t *testing.T) {
+	rnd := getRand(5)
+	res, err := GenerateWeightsFrom(testingGraph, UniformSampler(1, 11, rnd), false, true)
+	assert.Nil(t, err)
+	checkWeights(t, res, 1, 10)
+	used := make(map[int]bool)
+	for _, v := range res.Weights() {
+		assert.False(t, used[v])
+		used[v] = true
+	}
+
+	_, err = GenerateWeightsFrom(testingGraph, UniformSampler(1, 5, rnd), false, true)
+	assert.ErrorIs(t, err, generator.ErrWeightsNotFound)
+}
+
+func TestUniqueShortestPaths(t *testing.T) {
+	rnd := getRand(7)
+	for _, graph := range []generator.Graph{testingGraph, Join(pathGraph(4), testingGraph)} {
+		res, err := GenerateWeightsFrom(graph, UniformSampler(1, 4, rnd), false, false)
+		assert.Nil(t, err)
+		weights := res.(generator.WeightedGraph).WeightsMap
+		err = EnsureUniqueShortestPaths(res.Edges(), weights, UniformSampler(1, 100, rnd), rnd)
+		assert.Nil(t, err)
+		neighbours := sortedNeighbours(res.Edges())
+		for k := range neighbours {
+			assert.Empty(t, findShortestPathTie(neighbours, weights, k))
+		}
+	}
+
+	cycle := pathGraph(4)
+	addEdge(cycle.EdgesMap, 0, 3)
+	weights := map[generator.WeightedEdge]int{}
+	for _, e := range listEdges(cycle.Edges()) {
+		weights[e] = 1
+	}
+	err := EnsureUniqueShortestPaths(cycle.Edges(), weights, UniformSampler(1, 2, rnd), rnd)
+	assert.ErrorIs(t, err, generator.ErrWeightsNotFound)
+}
+
+func TestScaleWeights(t *testing.T) {
+	weights := map[generator.WeightedEdge]float64{generator.CreateEdge(0, 1): 1.25, generator.CreateEdge(1, 2): -0.5}
+	scaled := ScaleWeights(weights, 2)
+	assert.Equal(t, 125, scaled[generator.CreateEdge(0, 1)])
+	assert.Equal(t, -50, scaled[generator.CreateEdge(1, 2)])
+	scaled[generator.CreateEdge(1, 2)] = 75
+	UnscaleWeights(scaled, weights, 2)
+	assert.Equal(t, 0.75, weights[generator.CreateEdge(1, 2)])
+}
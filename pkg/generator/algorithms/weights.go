@@ -2,18 +2,101 @@ package algorithms
 
 import (
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"math"
 	mrand "math/rand"
 )
 
-func valueGen(min, max int, rand *mrand.Rand) int {
-	if min == max {
-		return min
+// WeightSampler draws one value of weight from some distribution.
+type WeightSampler func() int
+
+// UniformSampler draws values uniformly from [min, max), or min if both bounds are equal.
+func UniformSampler(min, max int, rand *mrand.Rand) WeightSampler {
+	return func() int {
+		if min == max {
+			return min
+		}
+		return rand.Intn(max-min) + min
+	}
+}
+
+// NormalSampler draws rounded values of normal distribution clipped to [min, max].
+func NormalSampler(mean, stdDev float64, min, max int, rand *mrand.Rand) WeightSampler {
+	return func() int {
+		value := math.Round(rand.NormFloat64()*stdDev + mean)
+		return int(math.Max(math.Min(value, float64(max)), float64(min)))
+	}
+}
+
+// ExponentialSampler draws values of exponential distribution with passed rate
+// shifted to start at min, rounded down and clipped to max.
+func ExponentialSampler(rate float64, min, max int, rand *mrand.Rand) WeightSampler {
+	return func() int {
+		value := math.Floor(rand.ExpFloat64() / rate)
+		if value > float64(max-min) {
+			return max
+		}
+		return min + int(value)
 	}
-	var value int = 0
-	for value == 0 {
-		value = rand.Intn(max-min) + min
+}
+
+// ZipfSampler draws values from Zipf distribution with passed exponent on [min, max],
+// min is the most probable value.
+func ZipfSampler(exponent float64, min, max int, rand *mrand.Rand) WeightSampler {
+	zipf := mrand.NewZipf(rand, exponent, 1, uint64(max-min))
+	return func() int {
+		return min + int(zipf.Uint64())
+	}
+}
+
+// HistogramSampler draws one of the values with probability proportional to its weight.
+func HistogramSampler(values []int, weights []float64, rand *mrand.Rand) WeightSampler {
+	sums := make([]float64, len(weights))
+	total := 0.0
+	for k, v := range weights {
+		total += v
+		sums[k] = total
+	}
+	return func() int {
+		point := rand.Float64() * total
+		for k, v := range sums {
+			if point < v {
+				return values[k]
+			}
+		}
+		return values[len(values)-1]
+	}
+}
+
+// FloatWeightSampler draws one real value of weight from some distribution.
+type FloatWeightSampler func() float64
+
+// UniformFloatSampler draws values uniformly from [min, max).
+func UniformFloatSampler(min, max float64, rand *mrand.Rand) FloatWeightSampler {
+	return func() float64 {
+		return rand.Float64()*(max-min) + min
+	}
+}
+
+// NormalFloatSampler draws values of normal distribution clipped to [min, max].
+func NormalFloatSampler(mean, stdDev, min, max float64, rand *mrand.Rand) FloatWeightSampler {
+	return func() float64 {
+		return math.Max(math.Min(rand.NormFloat64()*stdDev+mean, max), min)
+	}
+}
+
+// ExponentialFloatSampler draws values of exponential distribution with passed rate
+// shifted to start at min and clipped to max.
+func ExponentialFloatSampler(rate, min, max float64, rand *mrand.Rand) FloatWeightSampler {
+	return func() float64 {
+		return math.Min(min+rand.ExpFloat64()/rate, max)
+	}
+}
+
+// FloatSampler adapts discrete sampler to produce float values.
+func FloatSampler(sampler WeightSampler) FloatWeightSampler {
+	return func() float64 {
+		return float64(sampler())
 	}
-	return value
 }
 
 func GenerateWeights(graph generator.Graph, min, max int, rand *mrand.Rand) (generator.Graph, error) {
@@ -26,26 +109,109 @@ func GenerateWeights(graph generator.Graph, min, max int, rand *mrand.Rand) (gen
 	if min == 0 && max == 0 {
 		return graph, generator.ErrInvalidWeight
 	}
+	return GenerateWeightsFrom(graph, UniformSampler(min, max, rand), false, false)
+}
 
-	if graph.Properties().Weighted() {
-		return graph, nil
-	}
-	result := generator.WeightedGraph{ParentGraph: graph, WeightsMap: make(map[generator.WeightedEdge]int)}
+// maxDrawAttempts limits how many times the value of one edge is drawn.
+const maxDrawAttempts = 10000
 
+// assignWeights draws value for every edge in order of nodes. Zero values, unless allowed,
+// and already used values, when distinct values are requested, are drawn again.
+func assignWeights[T int | float64](graph generator.Graph, draw func() T, allowZero, distinct bool) (map[generator.WeightedEdge]T, error) {
+	weights := make(map[generator.WeightedEdge]T)
+	used := make(map[T]bool)
 	dimensions := len(graph.Edges())
 
 	for i := 0; i < dimensions; i++ {
 		for j := i + 1; j < dimensions; j++ {
-			if !result.Edges()[i][j] {
+			if !graph.Edges()[i][j] {
 				continue
 			}
-			value := valueGen(min, max, rand)
+			value := draw()
+			for attempt := 1; (value == 0 && !allowZero) || (distinct && used[value]); attempt++ {
+				if attempt == maxDrawAttempts {
+					return nil, generator.ErrWeightsNotFound
+				}
+				value = draw()
+			}
+			used[value] = true
 			edge := generator.WeightedEdge{
 				Left:  i,
 				Right: j,
 			}
-			result.WeightsMap[edge] = value
+			weights[edge] = value
 		}
 	}
-	return result, nil
+	return weights, nil
+}
+
+// GenerateWeightsFrom assigns weights drawn from the sampler to all edges of the graph,
+// zero values are drawn again unless allowZero is set. If distinct is set all the weights
+// are pairwise different, so e.g. the minimum spanning tree is unique.
+func GenerateWeightsFrom(graph generator.Graph, sampler WeightSampler, allowZero, distinct bool) (generator.Graph, error) {
+	if sampler == nil {
+		return graph, generator.ErrInvalidWeight
+	}
+
+	if graph.Properties().Weighted() {
+		return graph, nil
+	}
+	weights, err := assignWeights(graph, sampler, allowZero, distinct)
+	if err != nil {
+		return graph, err
+	}
+	return generator.WeightedGraph{ParentGraph: graph, WeightsMap: weights}, nil
+}
+
+// RoundedSampler rounds values of the sampler to passed number of decimal places.
+func RoundedSampler(sampler FloatWeightSampler, decimals int) FloatWeightSampler {
+	scale := math.Pow10(decimals)
+	return func() float64 {
+		return math.Round(sampler()*scale) / scale
+	}
+}
+
+// GenerateFloatWeights assigns real valued weights drawn from the sampler and rounded to passed number
+// of decimal places to all edges of the graph, values rounded to zero are drawn again unless allowZero is set.
+func GenerateFloatWeights(graph generator.Graph, sampler FloatWeightSampler, decimals int, allowZero, distinct bool) (generator.Graph, error) {
+	if sampler == nil || decimals < 0 {
+		return graph, generator.ErrInvalidWeight
+	}
+
+	if graph.Properties().Weighted() {
+		return graph, nil
+	}
+	weights, err := assignWeights(graph, RoundedSampler(sampler, decimals), allowZero, distinct)
+	if err != nil {
+		return graph, err
+	}
+	return generator.FloatWeightedGraph{ParentGraph: graph, WeightsMap: weights, Decimals: decimals}, nil
+}
+
+// GenerateVertexWeights assigns weights drawn from the sampler and rounded to passed number of decimal
+// places to all vertices of the graph, values rounded to zero are drawn again unless allowZero is set.
+// Integer weights are generated by discrete sampler adapted by FloatSampler with zero decimals.
+func GenerateVertexWeights(graph generator.Graph, sampler FloatWeightSampler, decimals int, allowZero bool) (generator.Graph, error) {
+	if sampler == nil || decimals < 0 {
+		return graph, generator.ErrInvalidWeight
+	}
+
+	draw := RoundedSampler(sampler, decimals)
+	weights := make([]float64, len(graph.Edges()))
+	for k := range weights {
+		value := draw()
+		for attempt := 1; value == 0 && !allowZero; attempt++ {
+			if attempt == maxDrawAttempts {
+				return graph, generator.ErrWeightsNotFound
+			}
+			value = draw()
+		}
+		weights[k] = value
+	}
+
+	if attributed, ok := graph.(generator.AttributedGraph); ok {
+		attributed.VertexWeights, attributed.WeightDecimals = weights, decimals
+		return attributed, nil
+	}
+	return generator.AttributedGraph{ParentGraph: graph, VertexWeights: weights, WeightDecimals: decimals}, nil
 }
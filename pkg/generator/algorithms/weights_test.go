@@ -1,8 +1,11 @@
 package algorithms
 
 import (
+	"bytes"
+	"encoding/gob"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"github.com/stretchr/testify/assert"
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -58,3 +61,111 @@ func TestValidWeights(t *testing.T) {
 	assert.Nil(t, err)
 	checkWeights(t, res, 1, 1)
 }
+
+func TestWeightSamplers(t *testing.T) {
+	rnd := rand.New(rand.NewSource(25))
+	samplers := map[string]WeightSampler{
+		"uniform":     UniformSampler(-10, 10, rnd),
+		"normal":      NormalSampler(0, 20, -10, 10, rnd),
+		"exponential": ExponentialSampler(0.1, -10, 10, rnd),
+		"zipf":        ZipfSampler(1.5, -10, 10, rnd),
+	}
+	for name, sampler := range samplers {
+		t.Run(name, func(t *testing.T) {
+			res, err := GenerateWeightsFrom(testingGraph, sampler, false, false)
+			assert.Nil(t, err)
+			checkWeights(t, res, -10, 10)
+		})
+	}
+}
+
+func TestHistogramWeights(t *testing.T) {
+	rnd := rand.New(rand.NewSource(25))
+	sampler := HistogramSampler([]int{0, 3, 7}, []float64{5, 1, 0}, rnd)
+	res, err := GenerateWeightsFrom(testingGraph, sampler, false, false)
+	assert.Nil(t, err)
+	for _, v := range res.Weights() {
+		assert.Equal(t, 3, v)
+	}
+
+	res, err = GenerateWeightsFrom(testingGraph, UniformSampler(0, 0, rnd), true, false)
+	assert.Nil(t, err)
+	assert.Len(t, res.Weights(), 10)
+	for _, v := range res.Weights() {
+		assert.Equal(t, 0, v)
+	}
+
+	_, err = GenerateWeightsFrom(testingGraph, nil, true, false)
+	assert.Error(t, err)
+
+	// clipped normal distribution drawing only zeros
+	_, err = GenerateWeightsFrom(testingGraph, NormalSampler(100, 1, -10, 0, rnd), false, false)
+	assert.ErrorIs(t, err, generator.ErrWeightsNotFound)
+	_, err = GenerateFloatWeights(testingGraph, NormalFloatSampler(100, 1, -10, 0, rnd), 2, false, false)
+	assert.ErrorIs(t, err, generator.ErrWeightsNotFound)
+}
+
+func TestFloatWeights(t *testing.T) {
+	rnd := rand.New(rand.NewSource(25))
+	res, err := GenerateFloatWeights(testingGraph, UniformFloatSampler(-1, 1, rnd), 3, false, false)
+	assert.Nil(t, err)
+	assert.True(t, res.Properties().Float())
+	weights, decimals := generator.FloatWeights(res)
+	assert.Equal(t, 3, decimals)
+	assert.Len(t, weights, 10)
+	for _, v := range weights {
+		assert.NotEqual(t, 0.0, v)
+		assert.InDelta(t, 0, v, 1)
+		assert.InDelta(t, math.Round(v*1000)/1000, v, 1e-12)
+	}
+
+	var buff bytes.Buffer
+	var encoded generator.Graph = res
+	assert.Nil(t, gob.NewEncoder(&buff).Encode(&encoded))
+	var decoded generator.Graph
+	assert.Nil(t, gob.NewDecoder(&buff).Decode(&decoded))
+	decodedWeights, decodedDecimals := generator.FloatWeights(decoded)
+	assert.Equal(t, weights, decodedWeights)
+	assert.Equal(t, decimals, decodedDecimals)
+
+	_, err = GenerateFloatWeights(testingGraph, UniformFloatSampler(-1, 1, rnd), -1, false, false)
+	assert.Error(t, err)
+}
+
+func TestVertexWeights(t *testing.T) {
+	rnd := rand.New(rand.NewSource(13))
+	res, err := GenerateVertexWeights(testingGraph, FloatSampler(UniformSampler(-3, 3, rnd)), 0, false)
+	assert.Nil(t, err)
+	assert.True(t, res.Properties().VertexWeighted())
+	weights, decimals := generator.VertexWeights(res)
+	assert.Equal(t, 0, decimals)
+	assert.Len(t, weights, 5)
+	for _, v := range weights {
+		assert.NotEqual(t, 0.0, v)
+		assert.True(t, v >= -3 && v < 3)
+	}
+
+	edges := map[generator.WeightedEdge]generator.Attributes{
+		generator.CreateEdge(0, 1): {"capacity": generator.IntAttr(3)},
+	}
+	attributed := generator.AttributedGraph{ParentGraph: testingGraph, EdgeAttributes: edges}
+	res, err = GenerateVertexWeights(attributed, UniformFloatSampler(0, 1, rnd), 2, true)
+	assert.Nil(t, err)
+	named := generator.NamedGraph{ParentGraph: res, VertexNames: []string{"a", "b", "c", "d", "e"}}
+	assert.Equal(t, edges, generator.EdgeAttributes(named))
+	weights, decimals = generator.VertexWeights(named)
+	assert.Equal(t, 2, decimals)
+	assert.Len(t, weights, 5)
+
+	var buff bytes.Buffer
+	var encoded generator.Graph = named
+	assert.Nil(t, gob.NewEncoder(&buff).Encode(&encoded))
+	var decoded generator.Graph
+	assert.Nil(t, gob.NewDecoder(&buff).Decode(&decoded))
+	assert.Equal(t, edges, generator.EdgeAttributes(decoded))
+	decodedWeights, _ := generator.VertexWeights(decoded)
+	assert.Equal(t, weights, decodedWeights)
+
+	_, err = GenerateVertexWeights(testingGraph, FloatSampler(UniformSampler(0, 0, rnd)), 0, false)
+	assert.ErrorIs(t, err, generator.ErrWeightsNotFound)
+}
@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrInvalidAttribute = errors.New("invalid attribute")
+
+// ReservedAttributes are the names under which formats write weights and names of vertices
+// and edges together with their attributes, attributes can't use them.
+var ReservedAttributes = map[string]bool{"weight": true, "label": true}
+
+// AttributeKind is the type of value stored in the Attribute
+type AttributeKind uint8
+
+const (
+	StringKind AttributeKind = iota
+	IntKind
+	FloatKind
+)
+
+// Attribute is one typed value of vertex or edge attribute,
+// only the field selected by Kind is used.
+type Attribute struct {
+	Kind  AttributeKind
+	Str   string
+	Int   int
+	Float float64
+}
+
+func StringAttr(v string) Attribute {
+	return Attribute{Kind: StringKind, Str: v}
+}
+
+func IntAttr(v int) Attribute {
+	return Attribute{Kind: IntKind, Int: v}
+}
+
+func FloatAttr(v float64) Attribute {
+	return Attribute{Kind: FloatKind, Float: v}
+}
+
+// Value returns the stored value as string, int or float64.
+func (a Attribute) Value() any {
+	switch a.Kind {
+	case IntKind:
+		return a.Int
+	case FloatKind:
+		return a.Float
+	}
+	return a.Str
+}
+
+func (a Attribute) String() string {
+	switch a.Kind {
+	case IntKind:
+		return strconv.Itoa(a.Int)
+	case FloatKind:
+		return strconv.FormatFloat(a.Float, 'f', -1, 64)
+	}
+	return a.Str
+}
+
+// Attributes maps names of attributes to their values
+type Attributes map[string]Attribute
+
+// AttributedGraph adds vertex weights and attributes of vertices and edges to the parent graph.
+// VertexWeights are rounded to WeightDecimals decimal places and are nil for graphs
+// without vertex weights, VertexAttributes are either nil or have entry for every vertex.
+// Generation fills the vertex weights only, no option of request produces attributes.
+type AttributedGraph struct {
+	ParentGraph      Graph
+	VertexWeights    []float64
+	WeightDecimals   int
+	VertexAttributes []Attributes
+	EdgeAttributes   map[WeightedEdge]Attributes
+}
+
+func (a AttributedGraph) Nodes() []string {
+	return a.ParentGraph.Nodes()
+}
+
+func (a AttributedGraph) Edges() []map[int]bool {
+	return a.ParentGraph.Edges()
+}
+
+func (a AttributedGraph) Weights() map[WeightedEdge]int {
+	return a.ParentGraph.Weights()
+}
+
+func (a AttributedGraph) Properties() GraphProperties {
+	result := a.ParentGraph.Properties() | ATTRIBUTED
+	if a.VertexWeights != nil {
+		result |= VERTEX_WEIGHTED
+	}
+	return result
+}
+
+// ValidAttributeName accepts names made of letters, digits and underscores not starting
+// with digit, so every format can write them unquoted, except of ReservedAttributes.
+func ValidAttributeName(name string) bool {
+	if name == "" || ReservedAttributes[name] {
+		return false
+	}
+	for k, c := range name {
+		letter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		if !letter && (k == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks names of all attributes, it fails with ErrInvalidAttribute naming the first invalid one.
+func (a AttributedGraph) Validate() error {
+	for k, attributes := range a.VertexAttributes {
+		for name := range attributes {
+			if !ValidAttributeName(name) {
+				return fmt.Errorf("%w: name %q of vertex %d attribute", ErrInvalidAttribute, name, k)
+			}
+		}
+	}
+	for e, attributes := range a.EdgeAttributes {
+		for name := range attributes {
+			if !ValidAttributeName(name) {
+				return fmt.Errorf("%w: name %q of edge %d-%d attribute", ErrInvalidAttribute, name, e.Left, e.Right)
+			}
+		}
+	}
+	return nil
+}
+
+// unwrap returns the graph wrapped by g, or nil if g doesn't wrap any.
+func unwrap(g Graph) Graph {
+	switch v := g.(type) {
+	case NamedGraph:
+		return v.ParentGraph
+	case WeightedGraph:
+		return v.ParentGraph
+	case FloatWeightedGraph:
+		return v.ParentGraph
+	case AttributedGraph:
+		return v.ParentGraph
+	}
+	return nil
+}
+
+// FindAttributed returns the attributed graph wrapped anywhere in g.
+func FindAttributed(g Graph) (AttributedGraph, bool) {
+	for ; g != nil; g = unwrap(g) {
+		if v, ok := g.(AttributedGraph); ok {
+			return v, true
+		}
+	}
+	return AttributedGraph{}, false
+}
+
+// VertexWeights returns weights of vertices together with the number of decimal
+// places they should be printed with, nil if the vertices aren't weighted.
+func VertexWeights(g Graph) ([]float64, int) {
+	a, _ := FindAttributed(g)
+	return a.VertexWeights, a.WeightDecimals
+}
+
+// VertexAttributes returns attributes of all vertices, nil if there are none.
+func VertexAttributes(g Graph) []Attributes {
+	a, _ := FindAttributed(g)
+	return a.VertexAttributes
+}
+
+// EdgeAttributes returns attributes of edges, edges without attributes are missing.
+func EdgeAttributes(g Graph) map[WeightedEdge]Attributes {
+	a, _ := FindAttributed(g)
+	return a.EdgeAttributes
+}
@@ -0,0 +1,47 @@
+package decision
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"math/rand"
+)
+
+// EnumerateGraphsFromRequest returns all non-isomorphic graphs satisfying the base request,
+// fails with generator.ErrTooManyGraphs if there are more than limit of them.
+func EnumerateGraphsFromRequest(request api.GraphRequest, limit int) ([]generator.SimpleGraph, error) {
+	constraints := algorithms.EnumerationConstraints{
+		MinDegree: 0,
+		MaxDegree: request.Nodes - 1,
+		Edges:     -1,
+		Connected: request.Connected,
+	}
+	switch request.Type {
+	case api.ExactDeg:
+		constraints.MinDegree, constraints.MaxDegree = request.NodeDegree, request.NodeDegree
+	case api.BetweenDeg:
+		constraints.MinDegree, constraints.MaxDegree = request.NodeDegree, request.NodeDegreeMax
+	case api.AtLeastDeg:
+		constraints.MinDegree = request.NodeDegree
+	case api.AverageDeg:
+		// the generator adds edges until the average is reached, connected graphs start with spanning tree
+		constraints.Edges = int((float32(request.Nodes) * request.NodeDegreeAverage) / 2.0)
+		if request.Connected && constraints.Edges < request.Nodes-1 {
+			constraints.Edges = request.Nodes - 1
+		}
+	case api.Complete:
+		constraints.MinDegree = request.Nodes - 1
+	}
+	return algorithms.EnumerateGraphs(request.Nodes, constraints, limit)
+}
+
+// EnumeratedGraphResult creates result for one of the enumerated graphs,
+// weights are generated from the seed of the request same as for random graphs.
+// The result carries the error when the weights or names couldn't be generated.
+func EnumeratedGraphResult(request api.GraphRequest, graph generator.SimpleGraph) *api.GraphResult {
+	result, err := finishGraph(request, graph, rand.New(rand.NewSource(*request.Seed)))
+	if err != nil {
+		return &api.GraphResult{ID: request.ID, Error: err.Error()}
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package decision
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEnumeratedGraphResult(t *testing.T) {
+	seed := int64(3)
+	request := api.GraphRequest{ID: 5, Type: api.ExactDeg, Nodes: 4, NodeDegree: 2, Seed: &seed,
+		Weighted: true, WeightMin: 1, WeightMax: 9, WeightMode: api.DistinctWeights}
+	graphs, err := EnumerateGraphsFromRequest(request, 10)
+	assert.NoError(t, err)
+	assert.Len(t, graphs, 1)
+
+	result := EnumeratedGraphResult(request, graphs[0])
+	assert.Equal(t, api.Finished, result.Status())
+	assert.Len(t, result.Generated.Weights(), 4)
+
+	request.WeightMax = 2
+	result = EnumeratedGraphResult(request, graphs[0])
+	assert.Equal(t, api.Failed, result.Status())
+	assert.Nil(t, result.Generated)
+	assert.Equal(t, uint32(5), result.ID)
+}
+
+func TestEnumerateConnectedAverage(t *testing.T) {
+	request := api.GraphRequest{Type: api.AverageDeg, Nodes: 5, NodeDegreeAverage: 1, Connected: true}
+	graphs, err := EnumerateGraphsFromRequest(request, 10)
+	assert.NoError(t, err)
+	// non-isomorphic trees with five nodes: path, star and the spider
+	assert.Len(t, graphs, 3)
+	seed := int64(1)
+	request.Seed = &seed
+	for _, graph := range graphs {
+		assert.Equal(t, api.Finished, EnumeratedGraphResult(request, graph).Status())
+	}
+}
@@ -2,17 +2,91 @@ package decision
 
 import (
 	"errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/verify"
+	"math"
 	"math/rand"
 )
 
 func GenerateGraphFromRequest(request api.GraphRequest) (*api.GraphResult, error) {
-	var graph generator.Graph = nil
-	var err error = nil
 	src := rand.NewSource(*request.Seed)
 	rng := rand.New(src)
+	graph, err := generateGraph(request, rng)
+
+	if len(request.Operations) != 0 && err == nil {
+		graph, err = applyOperations(graph, request.Operations, rng)
+	}
+	if err != nil {
+		return &api.GraphResult{ID: request.ID, Generated: graph}, err
+	}
+	return finishGraph(request, graph, rng)
+}
+
+// finishGraph runs the steps following the generation of graph structure, which are shared
+// by random and enumerated graphs. Weights, vertex weights and names are generated, then the
+// graph is verified and the requested problems are solved.
+func finishGraph(request api.GraphRequest, graph generator.Graph, rng *rand.Rand) (*api.GraphResult, error) {
+	var err error
+	if request.Weighted {
+		graph, err = generateWeights(graph, request, rng)
+	}
+
+	if request.VertexWeighted && err == nil {
+		graph, err = generateVertexWeights(graph, request, rng)
+	}
+
+	if request.Naming != nil && err == nil {
+		graph, err = nameGraph(graph, *request.Naming, rng)
+	}
+	result := &api.GraphResult{ID: request.ID, Generated: graph}
+	if err != nil || graph == nil {
+		return result, err
+	}
+	if verr := verify.Graph(request, graph); verr != nil {
+		log.Warnf("Graph %d failed verification: %s", request.ID, verr)
+		result.Verification = verr.Error()
+	}
+	result.Solutions = analysis.Solve(graph, request.Solutions)
+	return result, nil
+}
+
+// generateWeights assigns integer or float weights to the graph as requested. Float weights
+// are scaled to integers for the weight modes, so the sums of weights are compared exactly.
+func generateWeights(graph generator.Graph, request api.GraphRequest, rng *rand.Rand) (generator.Graph, error) {
+	distinct := request.WeightMode == api.DistinctWeights
+	if request.FloatWeights {
+		sampler := algorithms.RoundedSampler(floatWeightSampler(request, rng), request.WeightPrecision)
+		result, err := algorithms.GenerateFloatWeights(graph, sampler, request.WeightPrecision, request.AllowZero, distinct)
+		weighted, ok := result.(generator.FloatWeightedGraph)
+		if err != nil || !ok {
+			return result, err
+		}
+		scale := math.Pow10(request.WeightPrecision)
+		scaled := algorithms.ScaleWeights(weighted.WeightsMap, request.WeightPrecision)
+		redraw := func() int {
+			return int(math.Round(sampler() * scale))
+		}
+		err = applyWeightMode(weighted.Edges(), scaled, redraw, request.WeightMode, rng)
+		algorithms.UnscaleWeights(scaled, weighted.WeightsMap, request.WeightPrecision)
+		return result, err
+	}
+	sampler := weightSampler(request, rng)
+	result, err := algorithms.GenerateWeightsFrom(graph, sampler, request.AllowZero, distinct)
+	weighted, ok := result.(generator.WeightedGraph)
+	if err != nil || !ok {
+		return result, err
+	}
+	return result, applyWeightMode(weighted.Edges(), weighted.WeightsMap, sampler, request.WeightMode, rng)
+}
+
+// generateGraph generates the base graph of request, without operations and weights.
+func generateGraph(request api.GraphRequest, rng *rand.Rand) (generator.Graph, error) {
+	var graph generator.Graph = nil
+	var err error = nil
 	switch request.Type {
 	case api.ExactDeg:
 		graph, err = algorithms.GenerateStegerWormald(request.Nodes, request.NodeDegree, request.Connected, rng)
@@ -28,8 +102,80 @@ func GenerateGraphFromRequest(request api.GraphRequest) (*api.GraphResult, error
 		return nil, errors.New("invalid graph request")
 	}
 
-	if request.Weighted && err == nil {
-		graph, _ = algorithms.GenerateWeights(graph, request.WeightMin, request.WeightMax, rng)
+	if (request.MixingSteps > 0 || request.Uniform) && request.Type != api.Complete && err == nil {
+		steps := request.MixingSteps
+		if steps == 0 {
+			steps = algorithms.DefaultMixingSteps(countEdges(graph))
+		}
+		graph, err = algorithms.MixEdgeSwitch(graph, steps, request.Connected, rng)
+	}
+	return graph, err
+}
+
+// generateVertexWeights assigns weights to vertices, drawn from the same distribution as edge weights.
+func generateVertexWeights(graph generator.Graph, request api.GraphRequest, rng *rand.Rand) (generator.Graph, error) {
+	if request.FloatWeights {
+		return algorithms.GenerateVertexWeights(graph, floatWeightSampler(request, rng), request.WeightPrecision, request.AllowZero)
+	}
+	return algorithms.GenerateVertexWeights(graph, algorithms.FloatSampler(weightSampler(request, rng)), 0, request.AllowZero)
+}
+
+func applyWeightMode(edges []map[int]bool, weights map[generator.WeightedEdge]int, redraw algorithms.WeightSampler,
+	mode api.WeightMode, rng *rand.Rand) error {
+	if mode == api.UniqueShortestPaths {
+		return algorithms.EnsureUniqueShortestPaths(edges, weights, redraw, rng)
+	}
+	return nil
+}
+
+func countEdges(graph generator.Graph) int {
+	count := 0
+	for _, v := range graph.Edges() {
+		count += len(v)
+	}
+	return count / 2
+}
+
+// weightSampler creates sampler for the weight distribution of request.
+func weightSampler(request api.GraphRequest, rng *rand.Rand) algorithms.WeightSampler {
+	min, max := request.WeightMin, request.WeightMax
+	dist := request.WeightDistribution
+	if dist == nil {
+		return algorithms.UniformSampler(min, max, rng)
+	}
+	switch dist.Type {
+	case api.NormalDist:
+		return algorithms.NormalSampler(dist.Mean, dist.StdDev, min, max, rng)
+	case api.ExponentialDist:
+		return algorithms.ExponentialSampler(dist.Rate, min, max, rng)
+	case api.ZipfDist:
+		return algorithms.ZipfSampler(dist.Exponent, min, max, rng)
+	case api.HistogramDist:
+		values := make([]int, len(dist.Histogram))
+		weights := make([]float64, len(dist.Histogram))
+		for k, v := range dist.Histogram {
+			values[k], weights[k] = v.Value, v.Weight
+		}
+		return algorithms.HistogramSampler(values, weights, rng)
+	}
+	return algorithms.UniformSampler(min, max, rng)
+}
+
+// floatWeightSampler creates sampler of real valued weights, distributions which
+// are discrete by nature are only converted to floats.
+func floatWeightSampler(request api.GraphRequest, rng *rand.Rand) algorithms.FloatWeightSampler {
+	min, max := float64(request.WeightMin), float64(request.WeightMax)
+	dist := request.WeightDistribution
+	if dist == nil {
+		return algorithms.UniformFloatSampler(min, max, rng)
+	}
+	switch dist.Type {
+	case api.UniformDist:
+		return algorithms.UniformFloatSampler(min, max, rng)
+	case api.NormalDist:
+		return algorithms.NormalFloatSampler(dist.Mean, dist.StdDev, min, max, rng)
+	case api.ExponentialDist:
+		return algorithms.ExponentialFloatSampler(dist.Rate, min, max, rng)
 	}
-	return &api.GraphResult{ID: request.ID, Generated: graph}, err
+	return algorithms.FloatSampler(weightSampler(request, rng))
 }
@@ -0,0 +1,30 @@
+package decision
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGeneratedGraphsPassVerification(t *testing.T) {
+	requests := []api.GraphRequest{
+		{Type: api.ExactDeg, Nodes: 20, NodeDegree: 3, Connected: true},
+		{Type: api.AtLeastDeg, Nodes: 15, NodeDegree: 4},
+		{Type: api.BetweenDeg, Nodes: 25, NodeDegree: 1, NodeDegreeMax: 3, Connected: true},
+		{Type: api.AverageDeg, Nodes: 30, NodeDegreeAverage: 2.5, Connected: true},
+		{Type: api.Complete, Nodes: 6, Weighted: true, WeightMin: -5, WeightMax: 5},
+		{Type: api.ExactDeg, Nodes: 10, NodeDegree: 4, Weighted: true, WeightMin: 1, WeightMax: 3,
+			FloatWeights: true, WeightPrecision: 2, WeightMode: api.UniqueShortestPaths},
+		{Type: api.BetweenDeg, Nodes: 12, NodeDegree: 2, NodeDegreeMax: 4, MixingSteps: 50,
+			Weighted: true, WeightMin: -3, WeightMax: 6},
+	}
+	for k, request := range requests {
+		for seed := int64(0); seed < 10; seed++ {
+			request.Seed = &seed
+			result, err := GenerateGraphFromRequest(request)
+			assert.NoError(t, err, k)
+			assert.Empty(t, result.Verification, k)
+			assert.Equal(t, api.Finished, result.Status())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package decision
+
+import (
+	"errors"
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"math/rand"
+)
+
+var ErrTooFewNames = errors.New("not enough vertex names for generated graph")
+
+// nameGraph wraps the graph into generator.NamedGraph with names chosen by the naming scheme of request.
+func nameGraph(graph generator.Graph, naming api.VertexNaming, rng *rand.Rand) (generator.Graph, error) {
+	nodes := len(graph.Edges())
+	var names []string
+	var err error
+	switch naming.Scheme {
+	case api.NumericNaming:
+		names = algorithms.NumericNames(nodes, 0)
+	case api.OneBasedNaming:
+		names = algorithms.NumericNames(nodes, 1)
+	case api.AlphabeticNaming:
+		names = algorithms.AlphabeticNames(nodes)
+	case api.CityNaming:
+		names, err = algorithms.CityNames(nodes, rng)
+	case api.ListNaming:
+		if len(naming.Names) < nodes {
+			return graph, ErrTooFewNames
+		}
+		names = naming.Names[:nodes]
+	}
+	if err != nil {
+		return graph, err
+	}
+	return generator.NamedGraph{ParentGraph: graph, VertexNames: names}, nil
+}
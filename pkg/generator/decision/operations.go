@@ -0,0 +1,93 @@
+package decision
+
+import (
+	"errors"
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"math/rand"
+)
+
+var (
+	ErrMissingOperand = errors.New("operation requires operand")
+	ErrTooManyNodes   = errors.New("operation result exceeds maximal number of nodes")
+)
+
+// generateOperand generates second graph of binary operation. The seed is always drawn
+// from the parent generator so the following steps don't depend on whether operand has its own seed.
+func generateOperand(request api.GraphRequest, rng *rand.Rand) (generator.Graph, error) {
+	seed := rng.Int63()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+	operandRng := rand.New(rand.NewSource(seed))
+	graph, err := generateGraph(request, operandRng)
+	if len(request.Operations) != 0 && err == nil {
+		graph, err = applyOperations(graph, request.Operations, operandRng)
+	}
+	return graph, err
+}
+
+// applyOperations runs the post-processing pipeline in order, size of every intermediate result
+// is checked before it is built.
+func applyOperations(graph generator.Graph, operations []api.GraphOperation, rng *rand.Rand) (generator.Graph, error) {
+	for _, op := range operations {
+		var operand generator.Graph
+		var err error
+		if op.Operation.Binary() {
+			if op.Operand == nil {
+				return graph, ErrMissingOperand
+			}
+			operand, err = generateOperand(*op.Operand, rng)
+			if err != nil {
+				return graph, err
+			}
+		}
+		if resultNodes(op.Operation, graph, operand) > configuration.Default().MaxNodes {
+			return graph, ErrTooManyNodes
+		}
+		switch op.Operation {
+		case api.ComplementOp:
+			graph = algorithms.Complement(graph)
+		case api.LineGraphOp:
+			graph = algorithms.LineGraph(graph)
+		case api.CartesianProductOp:
+			graph = algorithms.CartesianProduct(graph, operand)
+		case api.TensorProductOp:
+			graph = algorithms.TensorProduct(graph, operand)
+		case api.StrongProductOp:
+			graph = algorithms.StrongProduct(graph, operand)
+		case api.DisjointUnionOp:
+			graph = algorithms.DisjointUnion(graph, operand)
+		case api.JoinOp:
+			graph = algorithms.Join(graph, operand)
+		case api.SubdivisionOp:
+			graph = algorithms.Subdivision(graph)
+		case api.PermutationOp:
+			graph, err = algorithms.RandomPermutation(graph, rng)
+		default:
+			return graph, api.ErrInvalidOperation
+		}
+		if err != nil {
+			return graph, err
+		}
+	}
+	return graph, nil
+}
+
+// resultNodes computes number of nodes of the operation result before it is built.
+func resultNodes(operation api.OperationType, graph, operand generator.Graph) int {
+	nodes := len(graph.Edges())
+	switch operation {
+	case api.LineGraphOp:
+		return countEdges(graph)
+	case api.SubdivisionOp:
+		return nodes + countEdges(graph)
+	case api.CartesianProductOp, api.TensorProductOp, api.StrongProductOp:
+		return nodes * len(operand.Edges())
+	case api.DisjointUnionOp, api.JoinOp:
+		return nodes + len(operand.Edges())
+	}
+	return nodes
+}
@@ -147,3 +147,37 @@ wait2:
 	genService.Stop()
 
 }
+
+func TestFailedGeneration(t *testing.T) {
+	genService := New(1)
+	results := genService.GetRetriever()
+	if err := genService.Start(); err != nil {
+		t.Error("GenService start ended", err)
+	}
+
+	seed := int64(255)
+	request := api.GraphRequest{
+		Type:       api.ExactDeg,
+		Nodes:      3,
+		NodeDegree: 5,
+		Seed:       &seed,
+		ID:         4455,
+	}
+	if err := genService.PushRequest(request); err != nil {
+		t.Error("Push failed", err.Error())
+	}
+
+	timeout := time.NewTimer(2 * time.Second)
+	select {
+	case dat := <-results:
+		if dat.ID != 4455 || dat.Error == "" || dat.Generated != nil {
+			t.Error("Failure was not reported", dat)
+		}
+		if dat.Status() != api.Failed {
+			t.Error("Invalid status of failed result", dat.Status())
+		}
+	case <-timeout.C:
+		t.Error("Failure was not reported in time")
+	}
+	genService.Stop()
+}
@@ -15,6 +15,7 @@ var (
 	ErrStoppedService   = errors.New("cannot stop stopped service")
 	ErrCantStartService = errors.New("cannot start stopped service")
 	ErrPushStoppedSvc   = errors.New("can't push to stopped service")
+	ErrNoGraph          = errors.New("generator returned no graph")
 )
 
 type Service interface {
@@ -80,9 +81,12 @@ func (service *GenService) runner() {
 					log.Debugf("Generating %d", request.ID)
 				}
 				graph, err := decision.GenerateGraphFromRequest(request)
-				if err != nil || graph == nil {
-					log.Error(err)
-					continue
+				if err == nil && (graph == nil || graph.Generated == nil) {
+					err = ErrNoGraph
+				}
+				if err != nil {
+					log.Errorf("Generation of graph %d failed: %s", request.ID, err)
+					graph = &api.GraphResult{ID: request.ID, Error: err.Error()}
 				}
 				service.retirever <- graph
 			}
@@ -3,6 +3,7 @@ package generator
 import (
 	"encoding/gob"
 	"errors"
+	"math"
 )
 
 // WeightedEdge associates nodes into one edge
@@ -22,12 +23,16 @@ func init() {
 	gob.Register(SimpleGraph{})
 	gob.Register(WeightedGraph{})
 	gob.Register(NamedGraph{})
+	gob.Register(FloatWeightedGraph{})
+	gob.Register(AttributedGraph{})
 }
 
 var (
 	ErrInvalidWeight     = errors.New("invalid weights")
 	ErrInvalidProperties = errors.New("invalid graph properties")
 	ErrMissingRand       = errors.New("rand must be passed")
+	ErrTooManyGraphs     = errors.New("too many graphs satisfy the request")
+	ErrWeightsNotFound   = errors.New("weights satisfying the request couldn't be found")
 )
 
 // GraphProperties represents properties of ParentGraph
@@ -37,6 +42,9 @@ const (
 	NONE GraphProperties = 1 << iota
 	NAMED
 	WEIGHTED
+	FLOAT
+	ATTRIBUTED
+	VERTEX_WEIGHTED
 )
 
 func (g GraphProperties) Weighted() bool {
@@ -47,6 +55,18 @@ func (g GraphProperties) Named() bool {
 	return g&NAMED != 0
 }
 
+func (g GraphProperties) Float() bool {
+	return g&FLOAT != 0
+}
+
+func (g GraphProperties) Attributed() bool {
+	return g&ATTRIBUTED != 0
+}
+
+func (g GraphProperties) VertexWeighted() bool {
+	return g&VERTEX_WEIGHTED != 0
+}
+
 type SimpleGraph struct {
 	Size     int
 	EdgesMap []map[int]bool
@@ -110,6 +130,52 @@ func (w WeightedGraph) Properties() GraphProperties {
 	return w.ParentGraph.Properties() | WEIGHTED
 }
 
+// FloatWeightedGraph carries real valued weights rounded to Decimals decimal places,
+// Weights returns them rounded to integers for consumers not aware of float weights.
+type FloatWeightedGraph struct {
+	ParentGraph Graph
+	WeightsMap  map[WeightedEdge]float64
+	Decimals    int
+}
+
+func (f FloatWeightedGraph) Nodes() []string {
+	return f.ParentGraph.Nodes()
+}
+
+func (f FloatWeightedGraph) Edges() []map[int]bool {
+	return f.ParentGraph.Edges()
+}
+
+func (f FloatWeightedGraph) Weights() map[WeightedEdge]int {
+	result := make(map[WeightedEdge]int, len(f.WeightsMap))
+	for k, v := range f.WeightsMap {
+		result[k] = int(math.Round(v))
+	}
+	return result
+}
+
+func (f FloatWeightedGraph) Properties() GraphProperties {
+	return f.ParentGraph.Properties() | WEIGHTED | FLOAT
+}
+
+// FloatWeights returns weights of the graph as floats together with the number
+// of decimal places they should be printed with, integer weights have zero decimals.
+func FloatWeights(g Graph) (map[WeightedEdge]float64, int) {
+	switch v := g.(type) {
+	case FloatWeightedGraph:
+		return v.WeightsMap, v.Decimals
+	case NamedGraph:
+		return FloatWeights(v.ParentGraph)
+	case AttributedGraph:
+		return FloatWeights(v.ParentGraph)
+	}
+	result := make(map[WeightedEdge]float64)
+	for k, v := range g.Weights() {
+		result[k] = float64(v)
+	}
+	return result, 0
+}
+
 func CreateEdge(u, v int) WeightedEdge {
 	if v < u {
 		u, v = v, u
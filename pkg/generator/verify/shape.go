@@ -0,0 +1,164 @@
+package verify
+
+import "github.com/soch-fit/GraphGenerator/pkg/api"
+
+// unknown marks property of shape which can't be derived from the request.
+const unknown = -1
+
+// shape describes what is known about graph generated for request before it is generated,
+// connected is true only when the graph has to be connected.
+type shape struct {
+	nodes, edges         int
+	minDegree, maxDegree int
+	connected            bool
+}
+
+// requestShape derives the shape of graph generated for request and transforms it by the operations.
+func requestShape(request api.GraphRequest) shape {
+	n := request.Nodes
+	result := shape{nodes: n, edges: unknown, minDegree: 0, maxDegree: n - 1, connected: request.Connected || request.Type == api.Complete}
+	switch request.Type {
+	case api.ExactDeg:
+		result.minDegree, result.maxDegree = request.NodeDegree, request.NodeDegree
+		result.edges = n * request.NodeDegree / 2
+	case api.AtLeastDeg:
+		result.minDegree = request.NodeDegree
+	case api.BetweenDeg:
+		result.minDegree, result.maxDegree = request.NodeDegree, request.NodeDegreeMax
+	case api.AverageDeg:
+		// the generator adds edges until the average is reached, connected graphs start with spanning tree
+		result.edges = int((float32(n) * request.NodeDegreeAverage) / 2.0)
+		if request.Connected && result.edges < n-1 {
+			result.edges = n - 1
+		}
+	case api.Complete:
+		result.minDegree = n - 1
+		result.edges = n * (n - 1) / 2
+	}
+	for _, op := range request.Operations {
+		var operand shape
+		if op.Operand != nil {
+			operand = requestShape(*op.Operand)
+		}
+		result = result.apply(op.Operation, operand)
+	}
+	return result
+}
+
+func known(values ...int) bool {
+	for _, v := range values {
+		if v == unknown {
+			return false
+		}
+	}
+	return true
+}
+
+// add sums the values, the sum is unknown when any of them is. Constants passed
+// to the helpers must not be equal to unknown.
+func add(values ...int) int {
+	if !known(values...) {
+		return unknown
+	}
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// subtract returns difference of the values, unknown when any of them is.
+func subtract(a, b int) int {
+	if !known(a, b) {
+		return unknown
+	}
+	return a - b
+}
+
+// mul multiplies the values, the product is unknown when any of them is.
+func mul(values ...int) int {
+	if !known(values...) {
+		return unknown
+	}
+	product := 1
+	for _, v := range values {
+		product *= v
+	}
+	return product
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger value, unknown when any of them is.
+func maxInt(a, b int) int {
+	if !known(a, b) {
+		return unknown
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// apply transforms shape s by the operation with the operand, which is used by binary operations only.
+func (s shape) apply(operation api.OperationType, o shape) shape {
+	switch operation {
+	case api.ComplementOp:
+		result := shape{nodes: s.nodes, edges: unknown, minDegree: 0, maxDegree: unknown}
+		if known(s.nodes) {
+			result.edges = subtract(s.nodes*(s.nodes-1)/2, s.edges)
+			result.maxDegree = s.nodes - 1 - s.minDegree
+			if known(s.maxDegree) {
+				result.minDegree = s.nodes - 1 - s.maxDegree
+			}
+		}
+		return result
+	case api.LineGraphOp:
+		// edge uv is adjacent to other edges at u and v, line graph of connected graph is connected
+		result := shape{nodes: s.edges, edges: unknown, minDegree: 0, maxDegree: add(s.maxDegree, s.maxDegree, -2), connected: s.connected}
+		if s.minDegree > 0 {
+			result.minDegree = 2*s.minDegree - 2
+		}
+		if s.minDegree == s.maxDegree && s.maxDegree > 0 {
+			result.edges = mul(s.edges, s.maxDegree-1)
+		}
+		return result
+	case api.SubdivisionOp:
+		// new nodes in the middle of edges have degree two
+		result := shape{nodes: add(s.nodes, s.edges), edges: mul(2, s.edges), minDegree: minInt(s.minDegree, 2),
+			maxDegree: maxInt(s.maxDegree, 2), connected: s.connected}
+		if s.edges == 0 {
+			result.minDegree, result.maxDegree = s.minDegree, s.maxDegree
+		}
+		return result
+	case api.CartesianProductOp:
+		return shape{nodes: mul(s.nodes, o.nodes), edges: add(mul(s.nodes, o.edges), mul(o.nodes, s.edges)),
+			minDegree: s.minDegree + o.minDegree, maxDegree: add(s.maxDegree, o.maxDegree), connected: s.connected && o.connected}
+	case api.TensorProductOp:
+		return shape{nodes: mul(s.nodes, o.nodes), edges: mul(2, s.edges, o.edges),
+			minDegree: s.minDegree * o.minDegree, maxDegree: mul(s.maxDegree, o.maxDegree)}
+	case api.StrongProductOp:
+		return shape{nodes: mul(s.nodes, o.nodes), edges: add(mul(s.nodes, o.edges), mul(o.nodes, s.edges), mul(2, s.edges, o.edges)),
+			minDegree: s.minDegree + o.minDegree + s.minDegree*o.minDegree,
+			maxDegree: add(s.maxDegree, o.maxDegree, mul(s.maxDegree, o.maxDegree)), connected: s.connected && o.connected}
+	case api.DisjointUnionOp:
+		return shape{nodes: add(s.nodes, o.nodes), edges: add(s.edges, o.edges),
+			minDegree: minInt(s.minDegree, o.minDegree), maxDegree: maxInt(s.maxDegree, o.maxDegree)}
+	case api.JoinOp:
+		// every node is joined with all nodes of the other graph
+		result := shape{nodes: add(s.nodes, o.nodes), edges: add(s.edges, o.edges, mul(s.nodes, o.nodes)),
+			minDegree: 0, maxDegree: maxInt(add(s.maxDegree, o.nodes), add(o.maxDegree, s.nodes))}
+		if known(s.nodes, o.nodes) {
+			result.minDegree = minInt(s.minDegree+o.nodes, o.minDegree+s.nodes)
+			result.connected = s.nodes > 0 && o.nodes > 0
+		}
+		return result
+	}
+	// permutation only renumbers the nodes
+	return s
+}
@@ -0,0 +1,121 @@
+// Package verify checks that the generated graphs satisfy the requests they were generated from.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/algorithms"
+	"math"
+)
+
+var ErrVerificationFailed = errors.New("graph doesn't satisfy the request")
+
+func verificationError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrVerificationFailed, fmt.Sprintf(format, args...))
+}
+
+// Graph checks that the generated graph satisfies its request. Every graph has to be
+// simple with symmetric EdgesMap and weights in the requested range. Size, degrees, number
+// of edges and connectivity are checked against the shape expected after the operations.
+func Graph(request api.GraphRequest, graph generator.Graph) error {
+	if graph == nil {
+		return verificationError("no graph was generated")
+	}
+	edges := graph.Edges()
+	if err := verifySimple(edges); err != nil {
+		return err
+	}
+	if err := verifyStructure(requestShape(request), edges); err != nil {
+		return err
+	}
+	if request.Weighted {
+		return verifyWeights(request, graph, edges)
+	}
+	return nil
+}
+
+// verifySimple checks that EdgesMap is symmetric, refers to nodes of graph and has no loops.
+func verifySimple(edges []map[int]bool) error {
+	for k := range edges {
+		for next, ok := range edges[k] {
+			switch {
+			case !ok:
+				continue
+			case next < 0 || next >= len(edges):
+				return verificationError("edge %d-%d leads out of graph", k, next)
+			case next == k:
+				return verificationError("node %d has loop", k)
+			case !edges[next][k]:
+				return verificationError("edge %d-%d is not symmetric", k, next)
+			}
+		}
+	}
+	return nil
+}
+
+func countDegrees(edges []map[int]bool) []int {
+	degrees := make([]int, len(edges))
+	for k := range edges {
+		for _, ok := range edges[k] {
+			if ok {
+				degrees[k]++
+			}
+		}
+	}
+	return degrees
+}
+
+// verifyStructure checks size, degrees, number of edges and connectivity of graph,
+// properties which aren't known in the shape are skipped.
+func verifyStructure(expected shape, edges []map[int]bool) error {
+	if expected.nodes != unknown && len(edges) != expected.nodes {
+		return verificationError("graph has %d nodes instead of %d", len(edges), expected.nodes)
+	}
+	sum := 0
+	for k, degree := range countDegrees(edges) {
+		if expected.maxDegree == unknown && degree < expected.minDegree {
+			return verificationError("node %d has degree %d lower than %d", k, degree, expected.minDegree)
+		}
+		if expected.maxDegree != unknown && (degree < expected.minDegree || degree > expected.maxDegree) {
+			return verificationError("node %d has degree %d out of range %d-%d", k, degree, expected.minDegree, expected.maxDegree)
+		}
+		sum += degree
+	}
+	if expected.edges != unknown && sum/2 != expected.edges {
+		return verificationError("graph has %d edges instead of %d", sum/2, expected.edges)
+	}
+	if expected.connected && len(algorithms.Components(edges)) > 1 {
+		return verificationError("graph is not connected")
+	}
+	return nil
+}
+
+// verifyWeights checks that every edge has weight within the bounds of request, zero weights
+// are permitted only when requested. Float weights are checked with their rounding tolerance.
+func verifyWeights(request api.GraphRequest, graph generator.Graph, edges []map[int]bool) error {
+	weights, decimals := generator.FloatWeights(graph)
+	min, max := request.WeightBounds()
+	tolerance := 0.5 * math.Pow10(-decimals)
+	if !request.FloatWeights {
+		tolerance = 0
+	}
+	for k := range edges {
+		for next, ok := range edges[k] {
+			if !ok || next < k {
+				continue
+			}
+			weight, found := weights[generator.CreateEdge(k, next)]
+			switch {
+			case !found:
+				return verificationError("edge %d-%d has no weight", k, next)
+			case weight < float64(min)-tolerance || weight > float64(max)+tolerance:
+				return verificationError("edge %d-%d has weight %v out of range %d-%d", k, next, weight, min, max)
+			case weight == 0 && !request.AllowZero:
+				return verificationError("edge %d-%d has zero weight", k, next)
+			}
+		}
+	}
+	return nil
+}
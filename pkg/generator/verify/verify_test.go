@@ -0,0 +1,68 @@
+package verify
+
+import (
+	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	path := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	assert.NoError(t, Graph(api.GraphRequest{Type: api.BetweenDeg, Nodes: 3, NodeDegree: 1, NodeDegreeMax: 2, Connected: true}, path))
+
+	cases := map[string]struct {
+		request api.GraphRequest
+		graph   generator.Graph
+	}{
+		"degree":       {api.GraphRequest{Type: api.ExactDeg, Nodes: 3, NodeDegree: 2}, path},
+		"at least":     {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 3, NodeDegree: 2}, path},
+		"complete":     {api.GraphRequest{Type: api.Complete, Nodes: 3}, path},
+		"size":         {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 4}, path},
+		"average":      {api.GraphRequest{Type: api.AverageDeg, Nodes: 3, NodeDegreeAverage: 2}, path},
+		"asymmetric":   {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 2}, generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {}}}},
+		"loop":         {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 1}, generator.SimpleGraph{Size: 1, EdgesMap: []map[int]bool{{0: true}}}},
+		"out of graph": {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 1}, generator.SimpleGraph{Size: 1, EdgesMap: []map[int]bool{{3: true}}}},
+		"connected": {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 3, Connected: true},
+			generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true}, {}}}},
+		"weight range": {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 3, Weighted: true, WeightMin: 1, WeightMax: 1},
+			generator.WeightedGraph{ParentGraph: path, WeightsMap: map[generator.WeightedEdge]int{{Left: 0, Right: 1}: 1, {Left: 1, Right: 2}: 2}}},
+		"zero weight": {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 3, Weighted: true, WeightMin: 0, WeightMax: 1},
+			generator.WeightedGraph{ParentGraph: path, WeightsMap: map[generator.WeightedEdge]int{{Left: 0, Right: 1}: 1, {Left: 1, Right: 2}: 0}}},
+		"missing weight": {api.GraphRequest{Type: api.AtLeastDeg, Nodes: 3, Weighted: true, WeightMin: 0, WeightMax: 1},
+			generator.WeightedGraph{ParentGraph: path, WeightsMap: map[generator.WeightedEdge]int{{Left: 0, Right: 1}: 1}}},
+	}
+	for name, c := range cases {
+		assert.ErrorIs(t, Graph(c.request, c.graph), ErrVerificationFailed, name)
+	}
+
+	operated := api.GraphRequest{Type: api.Complete, Nodes: 3, Operations: []api.GraphOperation{{Operation: api.ComplementOp}}}
+	assert.NoError(t, Graph(operated, generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{}, {}, {}}}))
+	assert.ErrorIs(t, Graph(operated, path), ErrVerificationFailed)
+	assert.ErrorIs(t, Graph(operated, nil), ErrVerificationFailed)
+
+	// path of three nodes joined with single node is connected graph of four nodes and five edges
+	operand := api.GraphRequest{Type: api.Complete, Nodes: 1}
+	joined := api.GraphRequest{Type: api.BetweenDeg, Nodes: 3, NodeDegree: 1, NodeDegreeMax: 2, Connected: true,
+		Operations: []api.GraphOperation{{Operation: api.JoinOp, Operand: &operand}}}
+	fan := generator.SimpleGraph{Size: 4, EdgesMap: []map[int]bool{{1: true, 3: true}, {0: true, 2: true, 3: true}, {1: true, 3: true}, {0: true, 1: true, 2: true}}}
+	assert.NoError(t, Graph(joined, fan))
+	assert.ErrorIs(t, Graph(joined, path), ErrVerificationFailed)
+	star := generator.SimpleGraph{Size: 4, EdgesMap: []map[int]bool{{3: true}, {3: true}, {3: true}, {0: true, 1: true, 2: true}}}
+	assert.ErrorIs(t, Graph(joined, star), ErrVerificationFailed)
+}
+
+func TestRequestShape(t *testing.T) {
+	cycle := api.GraphRequest{Type: api.ExactDeg, Nodes: 4, NodeDegree: 2, Connected: true}
+	operand := api.GraphRequest{Type: api.Complete, Nodes: 2}
+	cycle.Operations = []api.GraphOperation{
+		{Operation: api.CartesianProductOp, Operand: &operand},
+		{Operation: api.SubdivisionOp},
+		{Operation: api.PermutationOp},
+	}
+	// cube of eight nodes and twelve edges, subdivided
+	assert.Equal(t, shape{nodes: 20, edges: 24, minDegree: 2, maxDegree: 3, connected: true}, requestShape(cycle))
+
+	cycle.Operations = []api.GraphOperation{{Operation: api.LineGraphOp}, {Operation: api.ComplementOp}}
+	assert.Equal(t, shape{nodes: 4, edges: 2, minDegree: 1, maxDegree: 1}, requestShape(cycle))
+}
@@ -1,8 +1,11 @@
 package memory
 
 import (
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
 	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/decision"
 	"github.com/soch-fit/GraphGenerator/pkg/generator/service"
 	"github.com/soch-fit/GraphGenerator/pkg/requests"
 	"math/rand"
@@ -52,6 +55,7 @@ type InMemoryService struct {
 	requests  TypedMap[uint32, api.GraphRequest]
 	batches   TypedMap[uint32, api.BatchRequest]
 	graphs    TypedMap[uint32, api.GraphResult]
+	stats     TypedMap[uint32, analysis.Report]
 	owners    TypedMap[string, ownerReference]
 	service   *service.GenService
 	stop      chan bool
@@ -66,6 +70,24 @@ func (i *InMemoryService) DeleteGraph(graphId uint32) error {
 	return requests.ErrFunctionNotImplemented
 }
 
+func (i *InMemoryService) CancelGraph(graphId uint32) error {
+	i.rwLock.Lock()
+	defer i.rwLock.Unlock()
+	request, ok := i.requests.Load(graphId)
+	if !ok {
+		return requests.ErrGraphNotFound
+	}
+	if request.BatchId != nil {
+		return requests.ErrBatchGraph
+	}
+	if request.Status.Done() {
+		return requests.ErrGraphFinished
+	}
+	request.Status = api.Cancelled
+	i.requests.Store(graphId, request)
+	return nil
+}
+
 func (i *InMemoryService) DeleteBatch(batchId uint32) error {
 	return requests.ErrFunctionNotImplemented
 }
@@ -109,6 +131,7 @@ func (i *InMemoryService) cleanUpDb() {
 
 	for _, v := range toDelete {
 		i.graphs.Delete(v)
+		i.stats.Delete(v)
 		i.requests.Delete(v)
 	}
 	toDelete = toDelete[:0]
@@ -130,6 +153,7 @@ func (i *InMemoryService) cleanUpDb() {
 	for _, v := range toDelete {
 		i.requests.Delete(v)
 		i.graphs.Delete(v)
+		i.stats.Delete(v)
 	}
 
 	for k, v := range ownRs {
@@ -218,6 +242,21 @@ func (i *InMemoryService) StoreNewRequest(request api.GraphRequest) (api.GraphRe
 	return request, err
 }
 
+func (i *InMemoryService) ImportGraph(request api.GraphRequest, graph generator.Graph) (api.GraphRequest, error) {
+	i.rwLock.RLock()
+	defer i.rwLock.RUnlock()
+	request, err := i.storeRequestUnsafe(request, nil)
+	if err != nil {
+		return request, err
+	}
+	if err = i.storeGraphUnsafe(&api.GraphResult{ID: request.ID, Generated: graph}); err != nil {
+		return request, err
+	}
+	request.Status, request.GeneratedNodes = api.Finished, len(graph.Edges())
+	i.addToOwner(request.Owner, request.ID, true)
+	return request, nil
+}
+
 func (i *InMemoryService) addToOwner(owner *string, id uint32, graph bool) {
 	if owner != nil {
 		owners, ok := i.owners.Load(*owner)
@@ -237,6 +276,15 @@ func (i *InMemoryService) addToOwner(owner *string, id uint32, graph bool) {
 }
 
 func (i *InMemoryService) StoreNewBatch(request api.BatchRequest) (api.BatchRequest, error) {
+	var enumerated []generator.SimpleGraph
+	if request.Enumerate {
+		var err error
+		enumerated, err = decision.EnumerateGraphsFromRequest(request.BaseGraph, configuration.Default().MaxBatchSize)
+		if err != nil {
+			return request, err
+		}
+		request.Number = len(enumerated)
+	}
 	i.rwLock.RLock()
 	defer i.rwLock.RUnlock()
 	ids := make([]uint32, request.Number)
@@ -249,7 +297,13 @@ func (i *InMemoryService) StoreNewBatch(request api.BatchRequest) (api.BatchRequ
 			panic("Kalm")
 		}
 		ids[j] = r.ID
-		i.service.PushRequest(r)
+		if request.Enumerate {
+			if err = i.storeGraphUnsafe(decision.EnumeratedGraphResult(r, enumerated[j])); err != nil {
+				return request, err
+			}
+		} else {
+			i.service.PushRequest(r)
+		}
 	}
 
 	request.ID = getRandomId(&i.batches)
@@ -323,19 +377,23 @@ func (i *InMemoryService) GetBatch(batchId uint32) (api.BatchRequest, error) {
 	}
 
 	status := res.Status
-	if status != api.Finished {
-		actualState := api.Finished
-		for _, k := range res.GraphsIDs {
-			if _, ok := i.graphs.Load(k); !ok {
-				actualState = api.NotFinished
-				break
-			}
+	actualState := api.Finished
+	for _, k := range res.GraphsIDs {
+		graph, ok := i.requests.Load(k)
+		if !ok || !graph.Status.Done() {
+			actualState = api.NotFinished
+			continue
 		}
-		if actualState != status {
-			res.Status = actualState
-			i.batches.Store(res.ID, res)
+		if graph.Status != api.Finished {
+			res.Failures = append(res.Failures, api.GraphBatchStatus{GraphId: k, Status: graph.Status, Error: graph.Error})
 		}
 	}
+	if actualState != status {
+		res.Status = actualState
+		stored := res
+		stored.Failures = nil
+		i.batches.Store(res.ID, stored)
+	}
 	return res, nil
 }
 
@@ -350,15 +408,41 @@ func (i *InMemoryService) GetGraph(graphId uint32) (api.GraphResult, error) {
 	return result, nil
 }
 
+func (i *InMemoryService) GetGraphStats(graphId uint32) (analysis.Report, error) {
+	i.rwLock.RLock()
+	defer i.rwLock.RUnlock()
+
+	if report, ok := i.stats.Load(graphId); ok {
+		return report, nil
+	}
+	result, ok := i.graphs.Load(graphId)
+	if !ok {
+		return analysis.Report{}, requests.ErrGraphNotFound
+	}
+	report := analysis.Analyze(result.Generated)
+	i.stats.Store(graphId, report)
+	return report, nil
+}
+
 func (i *InMemoryService) StoreGraph(graph *api.GraphResult) error {
 	i.rwLock.RLock()
 	defer i.rwLock.RUnlock()
+	return i.storeGraphUnsafe(graph)
+}
+
+func (i *InMemoryService) storeGraphUnsafe(graph *api.GraphResult) error {
 	val, ok := i.requests.Load(graph.ID)
 	if !ok {
 		return requests.ErrGraphDeleted
 	}
-	val.Status = api.Finished
-	i.graphs.Store(graph.ID, *graph)
+	if val.Status == api.Cancelled {
+		return nil
+	}
+	val.Status, val.Error = graph.Status(), graph.StatusError()
+	val.GeneratedNodes = graph.Size()
+	if val.Status.Generated() {
+		i.graphs.Store(graph.ID, *graph)
+	}
 	i.requests.Store(graph.ID, val)
 
 	return nil
@@ -384,21 +468,24 @@ func (i *InMemoryService) GetBatchResult(batchId uint32) ([]api.GraphResult, err
 	if !ok {
 		return []api.GraphResult{}, requests.ErrBatchNotFound
 	}
-	result := make([]api.GraphResult, len(batch.GraphsIDs))
+	result := make([]api.GraphResult, 0, len(batch.GraphsIDs))
 
-	for k, v := range batch.GraphsIDs {
+	for _, v := range batch.GraphsIDs {
 		graph, ok := i.requests.Load(v)
 		if !ok {
 			return []api.GraphResult{}, requests.ErrGraphNotFound
 		}
-		if graph.Status != api.Finished {
+		if !graph.Status.Done() {
 			return []api.GraphResult{}, requests.ErrUnfinishedGraphBatch
 		}
+		if !graph.Status.Generated() {
+			continue
+		}
 		res, ok := i.graphs.Load(v)
 		if !ok {
 			panic("This shouldn't have happened")
 		}
-		result[k] = res
+		result = append(result, res)
 	}
 	return result, nil
 }
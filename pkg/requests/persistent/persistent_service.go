@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"github.com/dgraph-io/badger/v4"
 	log "github.com/sirupsen/logrus"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
 	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/generator/decision"
 	"github.com/soch-fit/GraphGenerator/pkg/generator/service"
 	"github.com/soch-fit/GraphGenerator/pkg/requests"
 	"github.com/soch-fit/GraphGenerator/pkg/utils"
@@ -67,6 +70,18 @@ func (d DbGraphResult) GetPrefix() []byte {
 	return []byte("result-")
 }
 
+type DbGraphStats struct {
+	ID uint32
+}
+
+func (d DbGraphStats) GetKey() []byte {
+	return []byte(fmt.Sprintf("stats-%d", d.ID))
+}
+
+func (d DbGraphStats) GetPrefix() []byte {
+	return []byte("stats-")
+}
+
 type DbOwnerGraphId struct {
 	Session string
 	GraphId uint32
@@ -272,18 +287,51 @@ func handleDeleteGraph(graphId uint32) DbHandleFunc {
 		if e != nil {
 			return e
 		}
-		if graph.Status != api.Finished {
+		if !graph.Status.Done() {
 			return requests.ErrGraphNotGenerated
 		}
-		grId, resId := DbGraphRequest{ID: graphId}, DbGraphResult{ID: graphId}
+		grId, resId, statsId := DbGraphRequest{ID: graphId}, DbGraphResult{ID: graphId}, DbGraphStats{ID: graphId}
 		e = txn.Delete(grId.GetKey())
 		if e != nil {
 			return e
 		}
+		e = txn.Delete(statsId.GetKey())
+		if e != nil {
+			return e
+		}
 		return txn.Delete(resId.GetKey())
 	}
 }
 
+func handleCancelGraph(graphId uint32) DbHandleFunc {
+	return func(txn *badger.Txn) error {
+		var graph api.GraphRequest
+		e := getGraphRequest(graphId, &graph)(txn)
+		if e != nil {
+			return e
+		}
+		if graph.BatchId != nil {
+			return requests.ErrBatchGraph
+		}
+		if graph.Status.Done() {
+			return requests.ErrGraphFinished
+		}
+		graph.Status = api.Cancelled
+		return storeGraphRequest(&graph, false)(txn)
+	}
+}
+
+func (p *PersistentService) CancelGraph(graphId uint32) error {
+	if p.CheckMaintenance() {
+		return requests.ErrServiceMaintenance
+	}
+	err := p.dbHandle.Update(handleCancelGraph(graphId))
+	if err == badger.ErrKeyNotFound {
+		return requests.ErrGraphNotFound
+	}
+	return err
+}
+
 func (p *PersistentService) DeleteGraph(graphId uint32) error {
 	if p.CheckMaintenance() {
 		return requests.ErrServiceMaintenance
@@ -448,11 +496,45 @@ func (p *PersistentService) StoreNewRequest(request api.GraphRequest) (api.Graph
 	return request, err
 }
 
+func (p *PersistentService) ImportGraph(request api.GraphRequest, graph generator.Graph) (api.GraphRequest, error) {
+	if p.CheckMaintenance() {
+		return api.GraphRequest{}, requests.ErrServiceMaintenance
+	}
+	request.Timeout = time.Now().Add(configuration.Default().RequestTTL)
+	request.Status = api.NotFinished
+	err := p.dbHandle.Update(func(txn *badger.Txn) error {
+		e := storeGraphRequest(&request, true)(txn)
+		if e != nil {
+			return e
+		}
+		if request.Owner != nil {
+			e = updateGraphOwner(request.ID, *request.Owner, request.Timeout)(txn)
+		}
+		return e
+	})
+	if err != nil {
+		return request, err
+	}
+	if err = p.StoreGraph(&api.GraphResult{ID: request.ID, Generated: graph}); err != nil {
+		return request, err
+	}
+	request.Status, request.GeneratedNodes = api.Finished, len(graph.Edges())
+	return request, nil
+}
+
 func (p *PersistentService) StoreNewBatch(request api.BatchRequest) (api.BatchRequest, error) {
 	if p.CheckMaintenance() {
 		return api.BatchRequest{}, requests.ErrServiceMaintenance
 	}
-	if p.generator.FreeBand() < request.Number {
+	var enumerated []generator.SimpleGraph
+	if request.Enumerate {
+		var err error
+		enumerated, err = decision.EnumerateGraphsFromRequest(request.BaseGraph, configuration.Default().MaxBatchSize)
+		if err != nil {
+			return request, err
+		}
+		request.Number = len(enumerated)
+	} else if p.generator.FreeBand() < request.Number {
 		return request, errors.New("insufficient bandwidth")
 	}
 	baseGraph := request.BaseGraph
@@ -504,11 +586,27 @@ func (p *PersistentService) StoreNewBatch(request api.BatchRequest) (api.BatchRe
 			return request, err
 		}
 	}
+	// enumerated graphs are stored in the same transaction, so the batch is never left partial
+	for k, v := range graphRequests {
+		if !request.Enumerate {
+			break
+		}
+		err = storeGraphResult(decision.EnumeratedGraphResult(v, enumerated[k]))(txn)
+		if err != nil {
+			txn.Discard()
+			return request, err
+		}
+	}
 	err = txn.Commit()
-	for _, v := range graphRequests {
-		p.generator.PushRequest(v)
+	if err != nil {
+		return request, err
 	}
-	return request, err
+	if !request.Enumerate {
+		for _, v := range graphRequests {
+			p.generator.PushRequest(v)
+		}
+	}
+	return request, nil
 
 }
 
@@ -558,21 +656,31 @@ func (p *PersistentService) StoreGraph(graph *api.GraphResult) error {
 	if p.CheckMaintenance() {
 		p.maintenanceStop.Wait()
 	}
-	return p.dbHandle.Update(func(txn *badger.Txn) error {
+	return p.dbHandle.Update(storeGraphResult(graph))
+}
+
+func storeGraphResult(graph *api.GraphResult) DbHandleFunc {
+	return func(txn *badger.Txn) error {
 		id := DbGraphResult{graph.ID}
 		var graphRequest api.GraphRequest
 		err := getGraphRequest(graph.ID, &graphRequest)(txn)
 		if err != nil {
 			return requests.ErrGraphDeleted
 		}
-		gr := marshall(*graph)
-		dur := time.Until(graphRequest.Timeout)
-		entry := badger.NewEntry(id.GetKey(), gr).WithTTL(dur)
-		err = txn.SetEntry(entry)
-		if err != nil {
-			return err
+		if graphRequest.Status == api.Cancelled {
+			return nil
+		}
+		graphRequest.Status, graphRequest.Error = graph.Status(), graph.StatusError()
+		graphRequest.GeneratedNodes = graph.Size()
+		if graphRequest.Status.Generated() {
+			gr := marshall(*graph)
+			dur := time.Until(graphRequest.Timeout)
+			entry := badger.NewEntry(id.GetKey(), gr).WithTTL(dur)
+			err = txn.SetEntry(entry)
+			if err != nil {
+				return err
+			}
 		}
-		graphRequest.Status = api.Finished
 		err = storeGraphRequest(&graphRequest, false)(txn)
 		if err != nil {
 			return err
@@ -581,7 +689,7 @@ func (p *PersistentService) StoreGraph(graph *api.GraphResult) error {
 			return updateBatch(*graphRequest.BatchId, graph.ID)(txn)
 		}
 		return nil
-	})
+	}
 }
 
 func (p *PersistentService) ListRequests(sessionId string) (result []uint32, e error) {
@@ -674,8 +782,32 @@ func (p *PersistentService) GetBatch(batchId uint32) (result api.BatchRequest, e
 			return nil
 		})
 	}
+	if err == nil {
+		err = p.dbHandle.View(batchFailures(result.GraphsIDs, &result.Failures))
+	}
 	return
 }
+
+// batchFailures collects graphs of batch which weren't generated or failed the verification.
+func batchFailures(graphIds []uint32, result *[]api.GraphBatchStatus) DbHandleFunc {
+	return func(txn *badger.Txn) error {
+		for _, graphId := range graphIds {
+			var request api.GraphRequest
+			err := getGraphRequest(graphId, &request)(txn)
+			if err == badger.ErrKeyNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if request.Status.Done() && request.Status != api.Finished {
+				*result = append(*result, api.GraphBatchStatus{GraphId: graphId, Status: request.Status, Error: request.Error})
+			}
+		}
+		return nil
+	}
+}
+
 func getGraphResult(graphId uint32, result *api.GraphResult) DbHandleFunc {
 	return func(txn *badger.Txn) error {
 		id := DbGraphResult{graphId}
@@ -700,6 +832,50 @@ func (p *PersistentService) GetGraph(graphId uint32) (res api.GraphResult, err e
 	return
 }
 
+func getGraphStats(graphId uint32, result *analysis.Report) DbHandleFunc {
+	return func(txn *badger.Txn) error {
+		id := DbGraphStats{graphId}
+		it, e := txn.Get(id.GetKey())
+		if e != nil {
+			return e
+		}
+		return it.Value(func(val []byte) error {
+			*result = unMarshall[analysis.Report](val)
+			return nil
+		})
+	}
+}
+
+func storeGraphStats(graphId uint32, report analysis.Report) DbHandleFunc {
+	return func(txn *badger.Txn) error {
+		var graphRequest api.GraphRequest
+		err := getGraphRequest(graphId, &graphRequest)(txn)
+		if err != nil {
+			return requests.ErrGraphDeleted
+		}
+		id := DbGraphStats{graphId}
+		entry := badger.NewEntry(id.GetKey(), marshall(report)).WithTTL(time.Until(graphRequest.Timeout))
+		return txn.SetEntry(entry)
+	}
+}
+
+func (p *PersistentService) GetGraphStats(graphId uint32) (report analysis.Report, err error) {
+	if p.CheckMaintenance() {
+		return analysis.Report{}, requests.ErrServiceMaintenance
+	}
+	err = p.dbHandle.View(getGraphStats(graphId, &report))
+	if err != badger.ErrKeyNotFound {
+		return
+	}
+	graph, err := p.GetGraph(graphId)
+	if err != nil {
+		return analysis.Report{}, err
+	}
+	report = analysis.Analyze(graph.Generated)
+	err = p.dbHandle.Update(storeGraphStats(graphId, report))
+	return
+}
+
 func (p *PersistentService) GetBatchResult(batchId uint32) (result []api.GraphResult, err error) {
 	if p.CheckMaintenance() {
 		return []api.GraphResult{}, requests.ErrServiceMaintenance
@@ -710,12 +886,19 @@ func (p *PersistentService) GetBatchResult(batchId uint32) (result []api.GraphRe
 		if e != nil {
 			return e
 		}
-		result = make([]api.GraphResult, graphRequest.Number)
-		for k, v := range graphRequest.GraphsIDs {
-			e = getGraphResult(v, &result[k])(txn)
+		result = make([]api.GraphResult, 0, graphRequest.Number)
+		for _, v := range graphRequest.GraphsIDs {
+			var request api.GraphRequest
+			e = getGraphRequest(v, &request)(txn)
+			if e == nil && request.Status.Done() && !request.Status.Generated() {
+				continue
+			}
+			var graph api.GraphResult
+			e = getGraphResult(v, &graph)(txn)
 			if e != nil {
 				return requests.ErrUnfinishedGraphBatch
 			}
+			result = append(result, graph)
 		}
 		return nil
 	})
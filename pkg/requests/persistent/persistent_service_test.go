@@ -4,6 +4,7 @@ import (
 	"github.com/soch-fit/GraphGenerator/pkg/api"
 	"github.com/soch-fit/GraphGenerator/pkg/configuration"
 	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/requests"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -146,3 +147,213 @@ func TestBatchStore(t *testing.T) {
 	assert.Len(t, genService.pushedGraphRequests, request.Number)
 
 }
+
+func TestEnumeratedBatchStore(t *testing.T) {
+	request := api.BatchRequest{
+		BaseGraph: api.GraphRequest{
+			Type:       api.ExactDeg,
+			Nodes:      8,
+			NodeDegree: 3,
+			Connected:  true,
+		},
+		Enumerate: true,
+	}
+
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	bat, err := ps.StoreNewBatch(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, bat.Number)
+	assert.Len(t, bat.GraphsIDs, 5)
+	assert.Len(t, genService.pushedGraphRequests, 0)
+
+	bat, err = ps.GetBatch(bat.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Finished, bat.Status)
+	graphs, err := ps.GetBatchResult(bat.ID)
+	assert.Nil(t, err)
+	assert.Len(t, graphs, 5)
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
+
+func TestImportGraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	owner := "importer"
+	graph := generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {0: true}}}
+	request := api.ImportedRequest(graph)
+	request.Owner = &owner
+	stored, err := ps.ImportGraph(request, graph)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Finished, stored.Status)
+	assert.Len(t, genService.pushedGraphRequests, 0)
+
+	saved, err := ps.GetGraphRequest(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Imported, saved.Type)
+	assert.Equal(t, api.Finished, saved.Status)
+	result, err := ps.GetGraph(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, graph.Edges(), result.Generated.Edges())
+	list, err := ps.ListRequests(owner)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{stored.ID}, list)
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
+
+func TestGetGraphStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	graph := generator.SimpleGraph{Size: 3, EdgesMap: []map[int]bool{{1: true}, {0: true, 2: true}, {1: true}}}
+	stored, err := ps.ImportGraph(api.ImportedRequest(graph), graph)
+	assert.Nil(t, err)
+	report, err := ps.GetGraphStats(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.Edges)
+	assert.Equal(t, 2, *report.Diameter)
+
+	err = ps.dbHandle.View(getGraphStats(stored.ID, &report))
+	assert.Nil(t, err)
+	cached, err := ps.GetGraphStats(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, report, cached)
+
+	_, err = ps.GetGraphStats(stored.ID + 1)
+	assert.ErrorIs(t, err, requests.ErrGraphNotFound)
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
+
+func TestStoreUnverifiedGraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	stored, err := ps.StoreNewRequest(api.GraphRequest{Type: api.ExactDeg, Nodes: 4, NodeDegree: 2})
+	assert.Nil(t, err)
+	graph := generator.SimpleGraph{Size: 4, EdgesMap: []map[int]bool{{1: true}, {0: true}, {}, {}}}
+	err = ps.StoreGraph(&api.GraphResult{ID: stored.ID, Generated: graph, Verification: "node 2 has degree 0"})
+	assert.Nil(t, err)
+
+	saved, err := ps.GetGraphRequest(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.VerificationFailed, saved.Status)
+	assert.Equal(t, "node 2 has degree 0", saved.Error)
+	assert.Equal(t, 4, saved.GeneratedNodes)
+	_, err = ps.GetGraph(stored.ID)
+	assert.Nil(t, err)
+	assert.Nil(t, ps.DeleteGraph(stored.ID))
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
+
+func TestFailedGeneration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	bat, err := ps.StoreNewBatch(api.BatchRequest{BaseGraph: api.GraphRequest{Type: api.Complete, Nodes: 2}, Number: 3})
+	assert.Nil(t, err)
+	graph := generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {0: true}}}
+	assert.Nil(t, ps.StoreGraph(&api.GraphResult{ID: bat.GraphsIDs[0], Generated: graph}))
+	assert.Nil(t, ps.StoreGraph(&api.GraphResult{ID: bat.GraphsIDs[1], Error: "generation failed"}))
+	bat, err = ps.GetBatch(bat.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.NotFinished, bat.Status)
+	assert.Equal(t, []api.GraphBatchStatus{{GraphId: bat.GraphsIDs[1], Status: api.Failed, Error: "generation failed"}}, bat.Failures)
+
+	assert.Nil(t, ps.StoreGraph(&api.GraphResult{ID: bat.GraphsIDs[2], Generated: graph}))
+	bat, err = ps.GetBatch(bat.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Finished, bat.Status)
+	assert.Len(t, bat.Failures, 1)
+	graphs, err := ps.GetBatchResult(bat.ID)
+	assert.Nil(t, err)
+	assert.Len(t, graphs, 2)
+
+	failed, err := ps.GetGraphRequest(bat.GraphsIDs[1])
+	assert.Nil(t, err)
+	assert.Equal(t, api.Failed, failed.Status)
+	assert.Equal(t, "generation failed", failed.Error)
+	_, err = ps.GetGraph(bat.GraphsIDs[1])
+	assert.ErrorIs(t, err, requests.ErrGraphNotFound)
+	assert.Nil(t, ps.DeleteBatch(bat.ID))
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
+
+func TestCancelGraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Long test skipping")
+	}
+	dbRoot := t.TempDir()
+	configuration.SetTestingDBRoot(dbRoot)
+	genService := buildGenSvcMock()
+	ps, err := New(&genService)
+	assert.Nil(t, err)
+	err = ps.Start()
+	assert.Nil(t, err)
+
+	stored, err := ps.StoreNewRequest(api.GraphRequest{Type: api.Complete, Nodes: 2})
+	assert.Nil(t, err)
+	assert.ErrorIs(t, ps.DeleteGraph(stored.ID), requests.ErrGraphNotGenerated)
+	assert.Nil(t, ps.CancelGraph(stored.ID))
+	graph := generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {0: true}}}
+	assert.Nil(t, ps.StoreGraph(&api.GraphResult{ID: stored.ID, Generated: graph}))
+
+	cancelled, err := ps.GetGraphRequest(stored.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Cancelled, cancelled.Status)
+	_, err = ps.GetGraph(stored.ID)
+	assert.ErrorIs(t, err, requests.ErrGraphNotFound)
+	assert.ErrorIs(t, ps.CancelGraph(stored.ID), requests.ErrGraphFinished)
+	assert.Nil(t, ps.DeleteGraph(stored.ID))
+	assert.ErrorIs(t, ps.CancelGraph(stored.ID), requests.ErrGraphNotFound)
+	_, err = ps.GetGraphRequest(stored.ID)
+	assert.NotNil(t, err)
+	err = ps.Stop()
+	assert.Nil(t, err)
+}
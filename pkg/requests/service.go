@@ -2,12 +2,16 @@ package requests
 
 import (
 	"errors"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
 )
 
 var (
 	ErrGraphNotFound          = errors.New("graph not found")
 	ErrGraphNotGenerated      = errors.New("graph not generated yet")
+	ErrGraphFinished          = errors.New("graph is already finished")
+	ErrBatchGraph             = errors.New("graph belongs to batch")
 	ErrBatchNotGenerated      = errors.New("batch not finished")
 	ErrUnfinishedGraphBatch   = errors.New("unfinished graph in batch")
 	ErrBatchNotFound          = errors.New("batch not found")
@@ -33,6 +37,10 @@ type RequestService interface {
 	// StoreGraph method stores result of graph generation and updates
 	StoreGraph(graph *api.GraphResult) error
 
+	// ImportGraph stores graph which wasn't generated together with its request,
+	// the request gets new Id, Timeout and Finished status.
+	ImportGraph(request api.GraphRequest, graph generator.Graph) (api.GraphRequest, error)
+
 	ListRequests(sessionId string) ([]uint32, error)
 
 	ListBatches(sessionId string) ([]uint32, error)
@@ -43,10 +51,21 @@ type RequestService interface {
 
 	GetGraph(graphId uint32) (api.GraphResult, error)
 
+	// GetGraphStats returns structural statistics of generated graph, the report is
+	// computed on the first call and kept as long as the graph.
+	GetGraphStats(graphId uint32) (analysis.Report, error)
+
 	GetBatchResult(batchId uint32) ([]api.GraphResult, error)
 
+	// DeleteGraph deletes graph together with its request, graphs which aren't finished
+	// yet have to be cancelled first.
 	DeleteGraph(graphId uint32) error
 
+	// CancelGraph stops generation of graph which isn't finished yet, its request is kept
+	// with Cancelled status and the graph is discarded when it is generated later.
+	// Graphs of batches can't be cancelled separately.
+	CancelGraph(graphId uint32) error
+
 	DeleteBatch(batchId uint32) error
 
 	Start() error
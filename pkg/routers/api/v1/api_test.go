@@ -1,8 +1,23 @@
 package v1
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/archive"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
+	"github.com/soch-fit/GraphGenerator/pkg/requests"
+	"github.com/soch-fit/GraphGenerator/pkg/routers/middleware"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -24,6 +39,11 @@ func (r RequestServiceMock) StoreGraph(graph *api.GraphResult) error {
 	panic("implement me")
 }
 
+func (r RequestServiceMock) ImportGraph(request api.GraphRequest, graph generator.Graph) (api.GraphRequest, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (r RequestServiceMock) ListRequests(sessionId string) ([]uint32, error) {
 	//TODO implement me
 	panic("implement me")
@@ -49,6 +69,11 @@ func (r RequestServiceMock) GetGraph(graphId uint32) (api.GraphResult, error) {
 	panic("implement me")
 }
 
+func (r RequestServiceMock) GetGraphStats(graphId uint32) (analysis.Report, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (r RequestServiceMock) GetBatchResult(batchId uint32) ([]api.GraphResult, error) {
 	//TODO implement me
 	panic("implement me")
@@ -59,6 +84,11 @@ func (r RequestServiceMock) DeleteGraph(graphId uint32) error {
 	panic("implement me")
 }
 
+func (r RequestServiceMock) CancelGraph(graphId uint32) error {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (r RequestServiceMock) DeleteBatch(batchId uint32) error {
 	//TODO implement me
 	panic("implement me")
@@ -75,8 +105,7 @@ func (r RequestServiceMock) Stop() error {
 }
 
 func (r RequestServiceMock) CheckMaintenance() bool {
-	//TODO implement me
-	panic("implement me")
+	return false
 }
 
 func TestBasicInit(t *testing.T) {
@@ -85,3 +114,290 @@ func TestBasicInit(t *testing.T) {
 	SetupREST(test, &mockRequests)
 
 }
+
+func TestFormats(t *testing.T) {
+	test := gin.New()
+	SetupREST(test, &RequestServiceMock{})
+
+	recorder := httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/formats", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var formats struct {
+		Formats []api.FormatInfo `json:"formats"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &formats))
+	assert.Equal(t, api.Formats(), formats.Formats)
+
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/graph/1/download?graphKind=pdf", nil))
+	assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"name":"matrix"`)
+
+	recorder = httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/batch/1/download", nil)
+	request.Header.Set("Accept", "application/pdf")
+	test.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/batch/1/download?graphKind=tikz&nodeStyle=draw%5D", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "nodeStyle")
+}
+
+// storedRequestsMock knows the requests of graphs, other methods panic like RequestServiceMock.
+type storedRequestsMock struct {
+	RequestServiceMock
+	requests map[uint32]api.GraphRequest
+}
+
+func (s storedRequestsMock) GetGraphRequest(graphId uint32) (api.GraphRequest, error) {
+	request, ok := s.requests[graphId]
+	if !ok {
+		return api.GraphRequest{}, requests.ErrGraphNotFound
+	}
+	return request, nil
+}
+
+func testBatch() (storedRequestsMock, []api.GraphResult) {
+	seed := int64(42)
+	service := storedRequestsMock{requests: map[uint32]api.GraphRequest{
+		1: {ID: 1, Type: api.Complete, Nodes: 2, Seed: &seed},
+	}}
+	graphs := []api.GraphResult{
+		{ID: 1, Generated: generator.SimpleGraph{Size: 2, EdgesMap: []map[int]bool{{1: true}, {0: true}}}},
+		{ID: 2, Generated: generator.SimpleGraph{Size: 1, EdgesMap: []map[int]bool{{}}}},
+	}
+	return service, graphs
+}
+
+func TestRepresentable(t *testing.T) {
+	_, graphs := testBatch()
+	weighted := generator.FloatWeightedGraph{
+		ParentGraph: graphs[0].Generated,
+		WeightsMap:  map[generator.WeightedEdge]float64{{Left: 0, Right: 1}: 0.5},
+		Decimals:    1,
+	}
+	graphs = append(graphs, api.GraphResult{ID: 3, Generated: weighted})
+	translators := []api.GraphTranslator{&api.DotGraph{}, &api.METISGraph{}}
+	err := representable(translators, graphs)
+	assert.ErrorIs(t, err, api.ErrUnrepresentable)
+	assert.Contains(t, err.Error(), "graph 3")
+	assert.NoError(t, representable(translators, graphs[:2]))
+
+	err = convertGraph(&api.DIMACSGraph{ShortestPath: true}, nil, graphs[2])
+	assert.ErrorIs(t, err, api.ErrUnrepresentable)
+	assert.NoError(t, convertGraph(&api.DIMACSGraph{ShortestPath: true}, nil, graphs[0]))
+}
+
+func readZip(t *testing.T, data []byte) map[string][]byte {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range reader.File {
+		r, _ := f.Open()
+		files[f.Name], _ = io.ReadAll(r)
+	}
+	return files
+}
+
+func TestWriteBatchArchive(t *testing.T) {
+	service, graphs := testBatch()
+	buffer := bytes.Buffer{}
+	writer, _ := archive.New(archive.Zip, &buffer)
+	manifest := api.BatchManifest{BatchID: 7, Formats: []string{"matrix"}, Archive: archive.Zip}
+	assert.NoError(t, writeBatchArchive(writer, service, []api.GraphTranslator{&api.MatrixGraph{}}, &manifest, graphs))
+
+	files := readZip(t, buffer.Bytes())
+	assert.Len(t, files, 3)
+
+	var stored api.BatchManifest
+	assert.NoError(t, json.Unmarshal(files[ManifestFile], &stored))
+	assert.Equal(t, uint32(7), stored.BatchID)
+	assert.Len(t, stored.Graphs, 2)
+	for _, entry := range stored.Graphs {
+		assert.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256(files[entry.File])), entry.Checksum)
+	}
+	assert.Equal(t, "rngr-1.txt", stored.Graphs[0].File)
+	assert.Equal(t, int64(42), *stored.Graphs[0].Seed)
+	assert.Equal(t, api.Complete, stored.Graphs[0].Request.Type)
+	assert.Nil(t, stored.Graphs[1].Request)
+}
+
+func TestMultiFormatArchive(t *testing.T) {
+	service, graphs := testBatch()
+	buffer := bytes.Buffer{}
+	writer, _ := archive.New(archive.Zip, &buffer)
+	translators := []api.GraphTranslator{&api.DotGraph{}, &api.MatrixGraph{}, &api.SVGGraph{}}
+	manifest := api.BatchManifest{}
+	assert.NoError(t, writeBatchArchive(writer, service, translators, &manifest, graphs))
+
+	files := readZip(t, buffer.Bytes())
+	assert.Len(t, files, 7)
+	for _, name := range []string{"graphviz-dot/rngr-1.dot", "matrix/rngr-2.txt", "svg/rngr-1.svg", ManifestFile} {
+		assert.Contains(t, files, name)
+	}
+	assert.Len(t, manifest.Graphs, 6)
+	assert.Equal(t, "svg", manifest.Graphs[2].Format)
+	assert.Equal(t, "svg/rngr-1.svg", manifest.Graphs[2].File)
+
+	test := gin.New()
+	SetupREST(test, &RequestServiceMock{})
+	recorder := httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/batch/1/download?graphKind=dot&graphKind=pdf", nil))
+	assert.Equal(t, http.StatusNotAcceptable, recorder.Code)
+}
+
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (f failingWriter) WriteFile(string, []byte) error {
+	return errWrite
+}
+
+func (f failingWriter) Close() error {
+	return nil
+}
+
+func TestBatchArchiveAbort(t *testing.T) {
+	service, graphs := testBatch()
+	manifest := api.BatchManifest{}
+	err := writeBatchArchive(failingWriter{}, service, []api.GraphTranslator{&api.MatrixGraph{}}, &manifest, graphs)
+	assert.ErrorIs(t, err, errWrite)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		abortStream(err)
+	})
+
+	test := gin.New()
+	SetupREST(test, &RequestServiceMock{})
+	recorder := httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/batch/1/download?archive=rar", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), archive.TarZst)
+}
+
+// panickingTranslator fails on the second graph, after the first has been written.
+type panickingTranslator struct {
+	api.Graph6Graph
+	converted int
+}
+
+func (p *panickingTranslator) Convert(g generator.Graph) bool {
+	if p.converted++; p.converted > 1 {
+		panic("translator failed")
+	}
+	return p.Graph6Graph.Convert(g)
+}
+
+// streamRecorder supports streaming of responses, which requires CloseNotify.
+type streamRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (s streamRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func TestBatchArchivePanic(t *testing.T) {
+	service, graphs := testBatch()
+	test := gin.New()
+	test.Use(middleware.Recover())
+	test.GET("/download", func(r *gin.Context) {
+		streamBatchFile(r, service, &panickingTranslator{}, 1, graphs)
+	})
+
+	recorder := streamRecorder{httptest.NewRecorder()}
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/download", nil))
+	})
+	assert.NotEmpty(t, recorder.Body.Bytes())
+}
+
+// importMock stores imported graphs, other methods panic like RequestServiceMock.
+type importMock struct {
+	RequestServiceMock
+	graphs map[uint32]generator.Graph
+}
+
+func (i importMock) ImportGraph(request api.GraphRequest, graph generator.Graph) (api.GraphRequest, error) {
+	request.ID = uint32(len(i.graphs) + 1)
+	request.Status = api.Finished
+	i.graphs[request.ID] = graph
+	return request, nil
+}
+
+func TestGraphImport(t *testing.T) {
+	service := importMock{graphs: make(map[uint32]generator.Graph)}
+	test := gin.New()
+	SetupREST(test, service)
+
+	recorder := httptest.NewRecorder()
+	body := bytes.NewBufferString("graph { a -- b [label=\"3\"]; b -- c }")
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/graph/import?format=dot", body))
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	var request api.GraphRequest
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &request))
+	assert.Equal(t, api.Imported, request.Type)
+	assert.Equal(t, 3, request.Nodes)
+	assert.True(t, request.Weighted)
+	assert.Equal(t, []string{"a", "b", "c"}, service.graphs[request.ID].Nodes())
+
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/graph/import?format=svg", bytes.NewBufferString("<svg/>")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "graph6")
+
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1/graph/import", bytes.NewBufferString("0 1\n0 0\n")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Len(t, service.graphs, 1)
+}
+
+type solvedGraphMock struct {
+	storedRequestsMock
+	graphs map[uint32]api.GraphResult
+}
+
+func (s solvedGraphMock) GetGraph(graphId uint32) (api.GraphResult, error) {
+	graph, ok := s.graphs[graphId]
+	if !ok {
+		return api.GraphResult{}, requests.ErrGraphNotFound
+	}
+	return graph, nil
+}
+
+func TestSolutions(t *testing.T) {
+	service, graphs := testBatch()
+	graphs[0].Solutions = analysis.Solve(graphs[0].Generated, []string{"mst", "bfs-order:1"})
+	buffer := bytes.Buffer{}
+	writer, _ := archive.New(archive.Zip, &buffer)
+	manifest := api.BatchManifest{}
+	assert.NoError(t, writeBatchArchive(writer, service, []api.GraphTranslator{&api.MatrixGraph{}}, &manifest, graphs))
+
+	files := readZip(t, buffer.Bytes())
+	assert.Len(t, files, 4)
+	var stored []analysis.Solution
+	assert.NoError(t, json.Unmarshal(files["solutions/rngr-1.json"], &stored))
+	assert.Equal(t, graphs[0].Solutions, stored)
+	assert.Len(t, manifest.Graphs, 3)
+	assert.Equal(t, SolutionsFolder, manifest.Graphs[1].Format)
+
+	test := gin.New()
+	SetupREST(test, solvedGraphMock{storedRequestsMock: service, graphs: map[uint32]api.GraphResult{1: graphs[0], 2: graphs[1]}})
+	recorder := httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/graph/1/solutions", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response struct {
+		Solutions []analysis.Solution `json:"solutions"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, []int{1, 0}, response.Solutions[1].Nodes)
+
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/graph/2/solutions", nil))
+	assert.JSONEq(t, `{"solutions": []}`, recorder.Body.String())
+	recorder = httptest.NewRecorder()
+	test.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/graph/3/solutions", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
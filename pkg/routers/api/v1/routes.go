@@ -1,24 +1,39 @@
 package v1
 
 import (
-	"archive/zip"
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
 	log "github.com/sirupsen/logrus"
+	"github.com/soch-fit/GraphGenerator/pkg/analysis"
 	"github.com/soch-fit/GraphGenerator/pkg/api"
+	"github.com/soch-fit/GraphGenerator/pkg/archive"
 	"github.com/soch-fit/GraphGenerator/pkg/configuration"
+	"github.com/soch-fit/GraphGenerator/pkg/generator"
 	"github.com/soch-fit/GraphGenerator/pkg/requests"
 	"github.com/soch-fit/GraphGenerator/pkg/routers/middleware"
 	"io"
 	"net/http"
+	"path"
 	"strconv"
 )
 
 var (
 	ErrInvalidRequest    = errors.New("invalid request body")
 	ErrInvalidAttributes = errors.New("attributes of request are invalid")
+	ErrConversionFailed  = errors.New("graph couldn't be converted to requested format")
+)
+
+const (
+	// ManifestFile is the name of file describing the graphs of batch archive.
+	ManifestFile = "manifest.json"
+	// SolutionsFolder is the folder of batch archive with answers to the requested problems.
+	SolutionsFolder = "solutions"
+	// maxImportSize bounds size of data of imported graph.
+	maxImportSize = 16 << 20
 )
 
 func handleGraphList(r *gin.Context) {
@@ -96,6 +111,53 @@ func handleGraphCreate(r *gin.Context) {
 	r.JSON(201, newReq)
 }
 
+// handleGraphImport parses graph of format given by format parameter from the request
+// body and stores it as finished graph of imported type.
+func handleGraphImport(r *gin.Context) {
+	data, err := io.ReadAll(io.LimitReader(r.Request.Body, maxImportSize+1))
+	if err != nil {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, err))
+		return
+	}
+	if len(data) > maxImportSize {
+		r.JSON(http.StatusRequestEntityTooLarge, api.NewErr(ErrInvalidRequest, nil))
+		return
+	}
+
+	graph, err := api.ParseGraph(r.DefaultQuery("format", api.DefaultFormat), data, r.Request.URL.Query())
+	if errors.Is(err, api.ErrImportNotSupported) {
+		r.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "formats": api.ImportFormats()})
+		return
+	}
+	if err != nil {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, err))
+		return
+	}
+	req := api.ImportedRequest(graph)
+	if !req.ValidImport() {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidAttributes, nil))
+		return
+	}
+
+	own, ok := r.Get("identifier")
+	if ok {
+		owner := own.(string)
+		req.Owner = &owner
+	}
+
+	service := getRequestsService(r)
+	newReq, err := service.ImportGraph(req, graph)
+	if errors.Is(err, requests.ErrServiceMaintenance) {
+		r.JSON(http.StatusServiceUnavailable, api.NewErr(err, nil))
+		return
+	}
+	if err != nil {
+		r.JSON(http.StatusInternalServerError, gin.H{"error": "could not store graph"})
+		return
+	}
+	r.JSON(http.StatusCreated, newReq)
+}
+
 func handleGraphDownload(r *gin.Context) {
 	var graphId uint32
 
@@ -106,17 +168,26 @@ func handleGraphDownload(r *gin.Context) {
 	}
 	graphId = uint32(grId)
 
+	translator, ok := getGraphFormat(r)
+	if !ok {
+		return
+	}
 	requestService := getRequestsService(r)
 
-	translator := getGraphFormat(r)
-
 	v, err := requestService.GetGraph(graphId)
 	if err != nil {
 		r.Error(err)
 		return
 	}
 
-	translator.Convert(v.Generated)
+	if err = convertGraph(translator, graphRequest(requestService, graphId), v); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, api.ErrUnrepresentable) {
+			status = http.StatusUnprocessableEntity
+		}
+		r.JSON(status, api.NewErr(err, nil))
+		return
+	}
 	data := translator.Bytes()
 	reader := bytes.NewReader(data)
 	attachment := fmt.Sprintf(`attachment; filename="rngr-%d.%s"`, graphId, translator.Extension())
@@ -124,6 +195,54 @@ func handleGraphDownload(r *gin.Context) {
 
 }
 
+func handleGraphStats(r *gin.Context) {
+	grId, err := strconv.Atoi(r.Param("graphId"))
+	if err != nil || grId < 0 {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, err))
+		return
+	}
+
+	report, err := getRequestsService(r).GetGraphStats(uint32(grId))
+	switch {
+	case errors.Is(err, requests.ErrGraphNotFound):
+		r.JSON(http.StatusNotFound, api.NewErr(err, nil))
+		return
+	case errors.Is(err, requests.ErrServiceMaintenance):
+		r.JSON(http.StatusServiceUnavailable, api.NewErr(err, nil))
+		return
+	case err != nil:
+		r.JSON(http.StatusInternalServerError, api.NewErr(err, nil))
+		return
+	}
+	r.JSON(http.StatusOK, report)
+}
+
+func handleGraphSolutions(r *gin.Context) {
+	grId, err := strconv.Atoi(r.Param("graphId"))
+	if err != nil || grId < 0 {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, err))
+		return
+	}
+
+	result, err := getRequestsService(r).GetGraph(uint32(grId))
+	switch {
+	case errors.Is(err, requests.ErrGraphNotFound):
+		r.JSON(http.StatusNotFound, api.NewErr(err, nil))
+		return
+	case errors.Is(err, requests.ErrServiceMaintenance):
+		r.JSON(http.StatusServiceUnavailable, api.NewErr(err, nil))
+		return
+	case err != nil:
+		r.JSON(http.StatusInternalServerError, api.NewErr(err, nil))
+		return
+	}
+	solutions := result.Solutions
+	if solutions == nil {
+		solutions = []analysis.Solution{}
+	}
+	r.JSON(http.StatusOK, gin.H{"solutions": solutions})
+}
+
 func handleBatchList(r *gin.Context) {
 	c, ok := r.Get("identifier")
 	if !ok {
@@ -151,7 +270,12 @@ func handleBatchCreate(r *gin.Context) {
 		return
 	}
 
-	if request.Number < 0 || request.Number > configuration.Default().MaxBatchSize {
+	if request.Enumerate {
+		if !request.ValidEnumeration() {
+			r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, ErrInvalidAttributes))
+			return
+		}
+	} else if request.Number < 0 || request.Number > configuration.Default().MaxBatchSize {
 		r.JSON(http.StatusBadRequest, gin.H{"error": "batch size outside of configuration"})
 		return
 	}
@@ -169,6 +293,10 @@ func handleBatchCreate(r *gin.Context) {
 
 	service := getRequestsService(r)
 	res, err := service.StoreNewBatch(request)
+	if errors.Is(err, generator.ErrTooManyGraphs) {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidAttributes, err))
+		return
+	}
 	if err != nil {
 		r.JSON(http.StatusInternalServerError, gin.H{"error": "could not store batch"})
 		return
@@ -216,25 +344,122 @@ func handleGraphDelete(r *gin.Context) {
 	r.Status(http.StatusNoContent)
 }
 
+// handleGraphCancel stops generation of graph, the cancelled request is kept until it is deleted.
+func handleGraphCancel(r *gin.Context) {
+	graphRaw, err := strconv.Atoi(r.Param("graphId"))
+	if err != nil || graphRaw < 0 {
+		r.JSON(http.StatusBadRequest, api.NewErr(ErrInvalidRequest, err))
+		return
+	}
+	err = getRequestsService(r).CancelGraph(uint32(graphRaw))
+	if err != nil {
+		r.Error(err)
+		return
+	}
+	r.Status(http.StatusNoContent)
+}
+
 func handleBatchDelete(r *gin.Context) {
 
 }
 
-func getGraphFormat(r *gin.Context) api.GraphTranslator {
-	rep := r.DefaultQuery("graphKind", "matrix")
-	var translator api.GraphTranslator
-
-	switch rep {
-	case "matrix":
-		translator = &api.MatrixGraph{}
-	case "JSON":
-		translator = &api.BasicJSONGraph{}
-	case "dot":
-		translator = &api.DotGraph{}
-	default:
-		translator = &api.MatrixGraph{}
-	}
-	return translator
+// getGraphFormat selects translator by graphKind query parameter or Accept header,
+// the client gets list of supported formats when none of them fits.
+func getGraphFormat(r *gin.Context) (api.GraphTranslator, bool) {
+	translator, err := api.NegotiateTranslator(r.Query("graphKind"), r.GetHeader("Accept"), r.Request.URL.Query())
+	if err != nil {
+		formatError(r, err)
+		return nil, false
+	}
+	return translator, true
+}
+
+// formatError responds to failed selection of format, invalid options are refused as bad request.
+func formatError(r *gin.Context, err error) {
+	if errors.Is(err, api.ErrInvalidOptions) {
+		r.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	r.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error(), "formats": api.Formats()})
+}
+
+// getGraphFormats selects translators for batch download, several formats may be
+// requested by repeating graphKind parameter.
+func getGraphFormats(r *gin.Context) ([]api.GraphTranslator, bool) {
+	kinds := r.QueryArray("graphKind")
+	if len(kinds) <= 1 {
+		translator, ok := getGraphFormat(r)
+		return []api.GraphTranslator{translator}, ok
+	}
+
+	result := make([]api.GraphTranslator, 0, len(kinds))
+	seen := make(map[string]bool)
+	for _, kind := range kinds {
+		translator, err := api.NegotiateTranslator(kind, "", r.Request.URL.Query())
+		if err != nil {
+			formatError(r, err)
+			return nil, false
+		}
+		if !seen[translator.Kind()] {
+			seen[translator.Kind()] = true
+			result = append(result, translator)
+		}
+	}
+	return result, true
+}
+
+func handleFormatsRequest(r *gin.Context) {
+	r.JSON(http.StatusOK, gin.H{"formats": api.Formats()})
+}
+
+// graphRequest returns the request graph was generated from or nil when it isn't stored anymore.
+func graphRequest(service requests.RequestService, graphId uint32) *api.GraphRequest {
+	request, err := service.GetGraphRequest(graphId)
+	if err != nil {
+		return nil
+	}
+	return &request
+}
+
+// convertGraph converts the graph, translators including the request in output
+// get the request the graph was generated from.
+func convertGraph(translator api.GraphTranslator, request *api.GraphRequest, graph api.GraphResult) error {
+	if rt, ok := translator.(api.RequestTranslator); ok {
+		rt.SetRequest(request)
+	}
+	if !translator.Convert(graph.Generated) {
+		if rt, ok := translator.(api.RepresentableTranslator); ok {
+			if err := rt.Representable(graph.Generated); err != nil {
+				return fmt.Errorf("graph %d: %w", graph.ID, err)
+			}
+		}
+		return fmt.Errorf("%w: graph %d", ErrConversionFailed, graph.ID)
+	}
+	return nil
+}
+
+// representable checks that all graphs of batch can be converted by translators,
+// so unsupported graphs are refused before the streaming begins.
+func representable(translators []api.GraphTranslator, graphs []api.GraphResult) error {
+	for _, translator := range translators {
+		rt, ok := translator.(api.RepresentableTranslator)
+		if !ok {
+			continue
+		}
+		for k := range graphs {
+			if err := rt.Representable(graphs[k].Generated); err != nil {
+				return fmt.Errorf("graph %d: %w", graphs[k].ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// abortStream stops streaming of response which has already begun, the connection is
+// closed so the client doesn't mistake truncated file for a complete one.
+func abortStream(err error) {
+	log.Warning("aborting download: ", err)
+	panic(http.ErrAbortHandler)
 }
 
 func handleBatchDownload(r *gin.Context) {
@@ -247,35 +472,140 @@ func handleBatchDownload(r *gin.Context) {
 	}
 	batchId = uint32(grId)
 
+	translators, ok := getGraphFormats(r)
+	if !ok {
+		return
+	}
+	archiveKind := r.Query("archive")
+	if archiveKind != "" && !archive.Supported(archiveKind) {
+		r.JSON(http.StatusBadRequest, gin.H{"error": archive.ErrUnsupportedArchive.Error(), "archives": archive.Kinds()})
+		return
+	}
 	service := getRequestsService(r)
 	graphs, err := service.GetBatchResult(batchId)
-	//batch, err := service.GetBatch(batchId)
 	if err != nil {
 		r.JSON(404, gin.H{"error": "batch couldn't be obtained", "reason": err.Error()})
 		return
 	}
+	if err = representable(translators, graphs); err != nil {
+		r.JSON(http.StatusUnprocessableEntity, api.NewErr(err, nil))
+		return
+	}
 
-	translator := getGraphFormat(r)
-	attachment := fmt.Sprintf(`attachment; filename=rnrg-%d-%s.zip`, batchId, translator.Kind())
+	ct, ok := translators[0].(api.ConcatTranslator)
+	if ok && ct.Concatenable() && len(translators) == 1 && archiveKind == "" {
+		streamBatchFile(r, service, ct, batchId, graphs)
+		return
+	}
+	if archiveKind == "" {
+		archiveKind = archive.Zip
+	}
+	manifest := api.BatchManifest{BatchID: batchId, Archive: archiveKind}
+	for _, translator := range translators {
+		manifest.Formats = append(manifest.Formats, translator.Kind())
+	}
+	attachment := fmt.Sprintf(`attachment; filename=rnrg-%d.%s`, batchId, archiveKind)
+	if len(translators) == 1 {
+		attachment = fmt.Sprintf(`attachment; filename=rnrg-%d-%s.%s`, batchId, translators[0].Kind(), archiveKind)
+	}
 	r.Writer.Header().Add("Content-Disposition", attachment)
+	r.Writer.Header().Set("Content-Type", archive.ContentType(archiveKind))
 
 	r.Stream(func(w io.Writer) bool {
-		zp := zip.NewWriter(w)
-		for k := range graphs {
-			fileName := fmt.Sprintf("rngr-%d.%s", graphs[k].ID, translator.Extension())
-			translator.Convert(graphs[k].Generated)
-			f, err := zp.Create(fileName)
-			if err != nil {
-				panic("something terrible happened")
+		writer, err := archive.New(archiveKind, w)
+		if err == nil {
+			err = writeBatchArchive(writer, service, translators, &manifest, graphs)
+		}
+		if err != nil {
+			abortStream(err)
+		}
+		return false
+	})
+}
+
+// writeBatchArchive converts graphs of batch into files of archive and finishes it with manifest.
+// Files of each format are put into folder named by the format when there are more formats.
+func writeBatchArchive(writer archive.Writer, service requests.RequestService, translators []api.GraphTranslator,
+	manifest *api.BatchManifest, graphs []api.GraphResult) error {
+	manifest.Graphs = make([]api.ManifestEntry, 0, len(graphs)*len(translators))
+	for k := range graphs {
+		request := graphRequest(service, graphs[k].ID)
+		for _, translator := range translators {
+			if err := convertGraph(translator, request, graphs[k]); err != nil {
+				return err
+			}
+			data := translator.Bytes()
+			entry := api.ManifestEntry{
+				ID:       graphs[k].ID,
+				Format:   translator.Kind(),
+				File:     fmt.Sprintf("rngr-%d.%s", graphs[k].ID, translator.Extension()),
+				Checksum: fmt.Sprintf("sha256:%x", sha256.Sum256(data)),
+				Request:  request,
 			}
-			_, err = translator.Serialize(f)
+			if len(translators) > 1 {
+				entry.File = path.Join(translator.Kind(), entry.File)
+			}
+			if request != nil {
+				entry.Seed = request.Seed
+			}
+			if err := writer.WriteFile(entry.File, data); err != nil {
+				return err
+			}
+			manifest.Graphs = append(manifest.Graphs, entry)
+		}
+		if len(graphs[k].Solutions) != 0 {
+			entry, err := writeSolutions(writer, graphs[k], request)
 			if err != nil {
-				panic("something else terrible had happened")
+				return err
 			}
+			manifest.Graphs = append(manifest.Graphs, entry)
 		}
-		err = zp.Close()
-		if err != nil {
-			panic("could not finalize zip")
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = writer.WriteFile(ManifestFile, data); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// writeSolutions writes answers to the problems requested for the graph into solutions folder.
+func writeSolutions(writer archive.Writer, graph api.GraphResult, request *api.GraphRequest) (api.ManifestEntry, error) {
+	data, err := json.MarshalIndent(graph.Solutions, "", "  ")
+	if err != nil {
+		return api.ManifestEntry{}, err
+	}
+	entry := api.ManifestEntry{
+		ID:       graph.ID,
+		Format:   SolutionsFolder,
+		File:     path.Join(SolutionsFolder, fmt.Sprintf("rngr-%d.json", graph.ID)),
+		Checksum: fmt.Sprintf("sha256:%x", sha256.Sum256(data)),
+		Request:  request,
+	}
+	if request != nil {
+		entry.Seed = request.Seed
+	}
+	return entry, writer.WriteFile(entry.File, data)
+}
+
+// streamBatchFile writes outputs of all graphs of batch one after another into one file.
+func streamBatchFile(r *gin.Context, service requests.RequestService, translator api.ConcatTranslator, batchId uint32, graphs []api.GraphResult) {
+	attachment := fmt.Sprintf(`attachment; filename=rnrg-%d.%s`, batchId, translator.Extension())
+	r.Writer.Header().Add("Content-Disposition", attachment)
+	r.Writer.Header().Set("Content-Type", translator.ContentType())
+
+	r.Stream(func(w io.Writer) bool {
+		for k := range graphs {
+			err := convertGraph(translator, graphRequest(service, graphs[k].ID), graphs[k])
+			if err == nil {
+				_, err = translator.Serialize(w)
+			}
+			if err != nil {
+				abortStream(err)
+			}
 		}
 		return false
 	})
@@ -299,11 +629,16 @@ func SetupREST(engine *gin.Engine, svc requests.RequestService) *gin.RouterGroup
 	r.Use(middleware.SetUpRequestService(svc))
 	r.Use(middleware.Error())
 	r.GET("/limits", handleLimitsRequest)
+	r.GET("/formats", handleFormatsRequest)
 	r.GET("graph", handleGraphList)
 	r.GET("graph/:graphId", handleGraphGet)
 	r.DELETE("graph/:graphId", handleGraphDelete)
 	r.POST("graph", handleGraphCreate)
+	r.POST("graph/import", handleGraphImport)
+	r.POST("graph/:graphId/cancel", handleGraphCancel)
 	r.GET("graph/:graphId/download", handleGraphDownload)
+	r.GET("graph/:graphId/stats", handleGraphStats)
+	r.GET("graph/:graphId/solutions", handleGraphSolutions)
 
 	r.GET("batch", handleBatchList)
 	r.POST("batch", handleBatchCreate)
@@ -50,10 +50,16 @@ func Recover() gin.HandlerFunc {
 	return func(context *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
 				log.Error("Middleware failed: ", r, context.Err())
-				if !context.Writer.Written() {
-					context.JSON(http.StatusInternalServerError, api.NewErr(ErrUnknownFailure, context.Err()))
+				if context.Writer.Written() {
+					// response has already begun, the connection is aborted so that
+					// the client can't mistake the truncated response for complete one
+					panic(http.ErrAbortHandler)
 				}
+				context.JSON(http.StatusInternalServerError, api.NewErr(ErrUnknownFailure, context.Err()))
 			}
 		}()
 		context.Next()
@@ -74,6 +80,12 @@ func Error() gin.HandlerFunc {
 			case requests.ErrUnfinishedGraphBatch:
 				context.JSON(http.StatusBadRequest, api.NewErr(err.Err, nil))
 				return
+			case requests.ErrGraphFinished:
+				context.JSON(http.StatusConflict, api.NewErr(err.Err, nil))
+				return
+			case requests.ErrBatchGraph:
+				context.JSON(http.StatusBadRequest, api.NewErr(err.Err, nil))
+				return
 			case requests.ErrGraphNotGenerated:
 				context.JSON(http.StatusMethodNotAllowed, api.NewErr(err.Err, nil))
 				return
@@ -42,6 +42,33 @@ func TestRecover(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, tester.Code)
 }
 
+func TestRecoverAbort(t *testing.T) {
+	sut := gin.New()
+	sut.Use(Recover())
+	sut.GET("/test", func(context *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		sut.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+
+func TestRecoverStarted(t *testing.T) {
+	sut := gin.New()
+	sut.Use(Recover())
+	sut.GET("/test", func(context *gin.Context) {
+		context.Writer.WriteString("partial")
+		panic("translator failed")
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		sut.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+
 func TestSetUpCookieMiddleware(t *testing.T) {
 	sut := gin.Default()
 	sut.Use(SetUpCookieMiddleware())
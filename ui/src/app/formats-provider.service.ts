@@ -0,0 +1,26 @@
+import {Injectable} from '@angular/core';
+import {Format, FormatList} from "./formats";
+import {HttpClient} from "@angular/common/http";
+
+@Injectable({
+  providedIn: 'root'
+})
+export class FormatsProviderService {
+
+  formats: Format[] = [
+    {name: "matrix", description: "Adjacency matrix", extension: "txt", content_type: "text/plain", media_types: [], concatenable: false},
+    {name: "dot", description: "Graphviz", extension: "dot", content_type: "text/plain", media_types: [], concatenable: false}
+  ]
+
+  private formatsEndpoint = "/api/v1/formats"
+
+  constructor(private httpClient: HttpClient) {
+    this.updateFormats()
+  }
+
+  updateFormats(): void {
+    this.httpClient.get<FormatList>(this.formatsEndpoint).subscribe(value => {
+      this.formats = value.formats
+    })
+  }
+}
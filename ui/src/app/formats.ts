@@ -0,0 +1,13 @@
+export interface Format {
+  name: string;
+  description: string;
+  extension: string;
+  content_type: string;
+  media_types: string[];
+  options?: string[];
+  concatenable: boolean;
+}
+
+export interface FormatList {
+  formats: Format[];
+}
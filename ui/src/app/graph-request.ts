@@ -5,6 +5,7 @@ export interface GraphRequest {
   id: Number;
   type: GraphType;
   nodes: Number;
+  generated_nodes?: Number;
   node_degree: Number;
   node_degree_max: Number;
   connected: Boolean;
@@ -15,4 +16,5 @@ export interface GraphRequest {
   weight_max: Number;
   deleted: Date;
   seed: Number;
+  error?: string;
 }
@@ -3,7 +3,8 @@ export enum GraphType {
   AVERAGE = "average-degree",
   BETWEEN = "between-degree",
   AT_LEAST = "at-least-degree",
-  COMPLETE = "complete"
+  COMPLETE = "complete",
+  IMPORTED = "imported"
 }
 
 export const keys = Object.keys(GraphType)
@@ -26,5 +27,7 @@ export function explanation(t: GraphType): string {
       return "Regular"
     case GraphType.AT_LEAST:
       return "At least"
+    case GraphType.IMPORTED:
+      return "Imported"
   }
 }
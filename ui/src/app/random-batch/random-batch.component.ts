@@ -11,6 +11,7 @@ import {Subject, takeUntil} from "rxjs";
 import {BaseComponent} from "../base.component";
 import {KeyValue} from "@angular/common";
 import {DateTime} from "luxon"
+import {FormatsProviderService} from "../formats-provider.service";
 
 @Component({
   selector: 'app-random-batch',
@@ -35,7 +36,8 @@ export class RandomBatchComponent extends BaseComponent implements OnInit {
   private interval: any
   private individual: any
 
-  constructor(private batchService: GraphBatchServiceService, private messageService: MessageService) {
+  constructor(private batchService: GraphBatchServiceService, private messageService: MessageService,
+              protected formatsProvider: FormatsProviderService) {
     super()
   }
 
@@ -4,13 +4,14 @@ import {GraphRequestService} from "../graph-request.service";
 import {explanation} from "../graph-type";
 import {MessageService} from "../message.service";
 import {Severity} from "../message";
-import {faCircleInfo, faDownload, faPlus} from "@fortawesome/free-solid-svg-icons";
+import {faCircleInfo, faDownload, faPlus, faTriangleExclamation} from "@fortawesome/free-solid-svg-icons";
 import {HttpErrorResponse} from "@angular/common/http";
 import {KeyValue} from "@angular/common";
-import {explain, getClass, RequestStatus} from "../request-status";
+import {downloadable, explain, getClass, RequestStatus} from "../request-status";
 import {takeUntil} from "rxjs";
 import {BaseComponent} from "../base.component";
 import {DateTime} from "luxon";
+import {FormatsProviderService} from "../formats-provider.service";
 
 @Component({
   selector: 'app-random-graph',
@@ -28,6 +29,8 @@ export class RandomGraphComponent extends BaseComponent implements OnInit {
   protected readonly faDownload = faDownload;
   protected readonly explain = explain;
   protected readonly getClass = getClass;
+  protected readonly downloadable = downloadable;
+  protected readonly faTriangleExclamation = faTriangleExclamation;
   protected readonly RequestStatus = RequestStatus;
   protected readonly faPlus = faPlus;
   protected readonly faCircleInfo = faCircleInfo;
@@ -36,7 +39,8 @@ export class RandomGraphComponent extends BaseComponent implements OnInit {
   private allRefresh: any
 
   constructor(private graphRequestService: GraphRequestService,
-              private messageService: MessageService) {
+              private messageService: MessageService,
+              protected formatsProvider: FormatsProviderService) {
     super()
   }
 
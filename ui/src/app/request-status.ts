@@ -1,6 +1,9 @@
 export enum RequestStatus {
   FINISHED = "finished",
   IN_PROGRESS = "not-finished",
+  VERIFICATION_FAILED = "verification-failed",
+  FAILED = "failed",
+  CANCELLED = "cancelled",
   DELETED = "deleted",
   UNDEFINED = "undefined"
 }
@@ -11,6 +14,12 @@ export function explain(g: RequestStatus): string {
       return "Finished"
     case RequestStatus.IN_PROGRESS:
       return "In progress"
+    case RequestStatus.VERIFICATION_FAILED:
+      return "Verification failed"
+    case RequestStatus.FAILED:
+      return "Failed"
+    case RequestStatus.CANCELLED:
+      return "Cancelled"
     case RequestStatus.DELETED:
       return "Deleted"
     case RequestStatus.UNDEFINED:
@@ -19,12 +28,23 @@ export function explain(g: RequestStatus): string {
   return ""
 }
 
+// downloadable tells whether the graph was generated, graphs which failed verification
+// can be downloaded too although they don't satisfy their request.
+export function downloadable(g: RequestStatus): boolean {
+  return g === RequestStatus.FINISHED || g === RequestStatus.VERIFICATION_FAILED
+}
+
 export function getClass(g: RequestStatus): string {
   switch (g) {
     case RequestStatus.FINISHED:
       return "bg-success"
     case RequestStatus.IN_PROGRESS:
       return "bg-primary"
+    case RequestStatus.VERIFICATION_FAILED:
+    case RequestStatus.FAILED:
+      return "bg-danger"
+    case RequestStatus.CANCELLED:
+      return "bg-secondary"
     case RequestStatus.DELETED:
       return "bg-secondary"
     case RequestStatus.UNDEFINED: